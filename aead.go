@@ -0,0 +1,96 @@
+package simple
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrAuthentication is returned, wrapped with more detail,
+//by a Reader from NewAEADReader for a frame that fails to authenticate.
+var ErrAuthentication = errors.New("simple: message authentication failed")
+
+//MaxAEADFrame is the largest frame, including nonce and tag,
+//accepted by a Reader from NewAEADReader.
+const MaxAEADFrame = 1 << 20
+
+//NewAEADReader returns a Reader of the plaintext of r,
+//a stream of frames sealed by aead.
+//
+//Each frame is a 4 byte big-endian length, as read by ReadFrame,
+//followed by that many bytes: a nonce of nonceSize bytes
+//and the output of aead.Seal with no additional data.
+//Frames longer than MaxAEADFrame are rejected with an error wrapping
+//ErrFrameTooLarge.
+//
+//The first frame that fails to authenticate stops the stream with an error
+//wrapping ErrAuthentication, and no plaintext from that frame is delivered.
+//Every later Read returns the same error.
+//A stream ending within a frame returns io.ErrUnexpectedEOF.
+//
+//Each frame is authenticated independently, so the Reader cannot detect
+//frames that are dropped, reordered, or repeated, or a stream cut short
+//between frames.
+//Callers that must can derive the nonces from a counter and check them.
+//
+//NewAEADReader panics if nonceSize is not aead.NonceSize().
+func NewAEADReader(r io.Reader, aead cipher.AEAD, nonceSize int) *Reader {
+	must(r)
+	if nonceSize != aead.NonceSize() {
+		panic("nonce size does not match AEAD")
+	}
+	return NewReader(&aeadReader{
+		src:       NewReader(r, WithMaxFrameSize(MaxAEADFrame)),
+		aead:      aead,
+		nonceSize: nonceSize,
+	})
+}
+
+type aeadReader struct {
+	src       *Reader
+	aead      cipher.AEAD
+	nonceSize int
+
+	plain []byte //undelivered plaintext of the current frame
+	frame int    //number of frames read
+	err   error
+}
+
+func (a *aeadReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(a.plain) == 0 {
+		if a.err != nil {
+			return 0, a.err
+		}
+		a.err = a.next()
+	}
+	n := copy(p, a.plain)
+	a.plain = a.plain[n:]
+	return n, nil
+}
+
+//next reads and opens the next frame.
+func (a *aeadReader) next() error {
+	payload, err := a.src.ReadFrame(binary.BigEndian, 4)
+	if err != nil {
+		return err
+	}
+	a.frame++
+
+	if len(payload) < a.nonceSize+a.aead.Overhead() {
+		return fmt.Errorf("%w: frame %d too short", ErrAuthentication, a.frame)
+	}
+	nonce, sealed := payload[:a.nonceSize], payload[a.nonceSize:]
+	//decrypt in place, as the payload is not used again
+	plain, err := a.aead.Open(sealed[:0], nonce, sealed, nil)
+	if err != nil {
+		return fmt.Errorf("%w: frame %d", ErrAuthentication, a.frame)
+	}
+	a.plain = plain
+	return nil
+}
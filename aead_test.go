@@ -0,0 +1,88 @@
+package simple
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func testAEAD(t *testing.T) cipher.AEAD {
+	t.Helper()
+	block, err := aes.NewCipher(make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+//seal returns each message sealed in a frame, with a counter nonce.
+func seal(aead cipher.AEAD, msgs ...string) []byte {
+	var out []byte
+	for i, m := range msgs {
+		nonce := make([]byte, aead.NonceSize())
+		binary.BigEndian.PutUint64(nonce[len(nonce)-8:], uint64(i))
+		frame := aead.Seal(nonce, nonce, []byte(m), nil)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(frame)))
+		out = append(out, frame...)
+	}
+	return out
+}
+
+func TestAEADReader(t *testing.T) {
+	aead := testAEAD(t)
+	stream := seal(aead, "Hello, ", "", "World!")
+	for _, sizes := range [][]int{{1}, {3, 20}, {len(stream)}} {
+		r := NewAEADReader(NewScriptedReader(stream, sizes), aead, aead.NonceSize())
+		got, err := io.ReadAll(iotest.OneByteReader(r))
+		if err != nil || string(got) != "Hello, World!" {
+			t.Errorf("%v: got %q, %v", sizes, got, err)
+		}
+	}
+}
+
+func TestAEADReaderTampered(t *testing.T) {
+	aead := testAEAD(t)
+	stream := seal(aead, "Hello, ", "World!", "again")
+	//flip a bit in the tag of the second frame
+	stream[len(seal(aead, "Hello, ", "World!"))-1] ^= 1
+
+	r := NewAEADReader(bytes.NewReader(stream), aead, aead.NonceSize())
+	got, err := io.ReadAll(r)
+	if string(got) != "Hello, " {
+		t.Errorf("got %q", got)
+	}
+	if !errors.Is(err, ErrAuthentication) {
+		t.Fatalf("got %v want ErrAuthentication", err)
+	}
+	if _, err2 := r.Read(make([]byte, 1)); err2 != err {
+		t.Errorf("got %v want %v", err2, err)
+	}
+}
+
+func TestAEADReaderMalformed(t *testing.T) {
+	aead := testAEAD(t)
+	stream := seal(aead, "Hello")
+	tests := map[string]struct {
+		in   []byte
+		want error
+	}{
+		"truncated": {stream[:len(stream)-1], io.ErrUnexpectedEOF},
+		"short":     {[]byte{0, 0, 0, 3, 1, 2, 3}, ErrAuthentication},
+		"too large": {[]byte{0, 0x10, 0, 1}, ErrFrameTooLarge},
+	}
+	for name, tt := range tests {
+		_, err := io.ReadAll(NewAEADReader(bytes.NewReader(tt.in), aead, aead.NonceSize()))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v want %v", name, err, tt.want)
+		}
+	}
+}
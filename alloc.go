@@ -0,0 +1,42 @@
+package simple
+
+//WithAllocator makes the Reader get the buffers it uses internally,
+//such as the cache of PeekAt and the read buffers of ContentChunks
+//and ReadStringN, from alloc instead of make,
+//and give each to free, if not nil, once it is done with it.
+//
+//alloc must return a slice of length n.
+//A buffer is only given to free once nothing refers to it,
+//but buffers still in use when the Reader is abandoned
+//are left to the garbage collector.
+//Buffers whose contents are returned to the caller,
+//such as the chunks of ContentChunks, are made as usual.
+//
+//WithAllocator panics if alloc is nil.
+func WithAllocator(alloc func(n int) []byte, free func([]byte)) Option {
+	if alloc == nil {
+		panic("nil allocator")
+	}
+	return func(r *Reader) {
+		r.alloc, r.free = alloc, free
+	}
+}
+
+//allocBuf returns a buffer of n bytes from the allocator, if any.
+func (r *Reader) allocBuf(n int) []byte {
+	if r.alloc == nil {
+		return make([]byte, n)
+	}
+	b := r.alloc(n)
+	if len(b) != n {
+		panic("allocator returned the wrong size")
+	}
+	return b
+}
+
+//freeBuf returns a buffer from allocBuf to the allocator, if any.
+func (r *Reader) freeBuf(b []byte) {
+	if r.free != nil && b != nil {
+		r.free(b)
+	}
+}
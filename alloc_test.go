@@ -0,0 +1,74 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+//countingAllocator hands out buffers and checks that they are freed.
+type countingAllocator struct {
+	allocs, frees int
+	live          map[*byte]bool
+}
+
+func (c *countingAllocator) alloc(n int) []byte {
+	c.allocs++
+	b := make([]byte, n)
+	if n > 0 {
+		c.live[&b[0]] = true
+	}
+	return b
+}
+
+func (c *countingAllocator) free(b []byte) {
+	c.frees++
+	if len(b) > 0 {
+		if !c.live[&b[0]] {
+			panic("freed a buffer not allocated")
+		}
+		delete(c.live, &b[0])
+	}
+}
+
+func TestWithAllocator(t *testing.T) {
+	c := &countingAllocator{live: map[*byte]bool{}}
+	r := NewReader(strings.NewReader("name\x00\x00rest of the stream"), WithAllocator(c.alloc, c.free), WithTrimNUL())
+
+	if s, err := r.ReadStringN(6, ASCII); s != "name" || err != nil {
+		t.Fatalf("got %q, %v", s, err)
+	}
+	if c.allocs != 1 || c.frees != 1 {
+		t.Errorf("ReadStringN: %d allocs, %d frees", c.allocs, c.frees)
+	}
+
+	var got []byte
+	for chunk, err := range r.ContentChunks(64) {
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		got = append(got, chunk...)
+	}
+	if string(got) != "rest of the stream" {
+		t.Errorf("got %q", got)
+	}
+	if c.allocs != 2 || c.frees != 2 || len(c.live) != 0 {
+		t.Errorf("ContentChunks: %d allocs, %d frees, %d live", c.allocs, c.frees, len(c.live))
+	}
+
+	//the cache is kept until a larger one is needed
+	if p, err := r.PeekAt(0, 4); string(p) != "name" || err != nil {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	r.PeekAt(2, 2)
+	if c.allocs != 3 || c.frees != 2 {
+		t.Errorf("PeekAt: %d allocs, %d frees", c.allocs, c.frees)
+	}
+	r.PeekAt(0, 2*peekWindow)
+	if c.allocs != 4 || c.frees != 3 || len(c.live) != 1 {
+		t.Errorf("PeekAt: %d allocs, %d frees, %d live", c.allocs, c.frees, len(c.live))
+	}
+}
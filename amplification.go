@@ -0,0 +1,84 @@
+package simple
+
+import (
+	"io"
+	"math"
+)
+
+//AmplificationReader is a Reader that compares the bytes read from
+//the wrapped io.Reader with the bytes delivered by a stack of readers
+//built on it.
+type AmplificationReader struct {
+	*Reader
+	a *amplificationReader
+}
+
+//NewAmplificationReader wraps r, the source of a stack of readers,
+//in an AmplificationReader counting the bytes read from r.
+//
+//Until Output is called, the bytes delivered are counted as they leave
+//the AmplificationReader, so it measures only itself.
+func NewAmplificationReader(r io.Reader) *AmplificationReader {
+	must(r)
+	a := &amplificationReader{r: r}
+	return &AmplificationReader{
+		Reader: NewReader(a),
+		a:      a,
+	}
+}
+
+//Output returns a Reader of r, the top of a stack of readers
+//built on the AmplificationReader,
+//and counts the bytes delivered by it, rather than by the AmplificationReader.
+func (a *AmplificationReader) Output(r io.Reader) *Reader {
+	must(r)
+	a.a.output = true
+	a.a.delivered = 0
+	return NewReader(&deliveredCounter{r: r, a: a.a})
+}
+
+//Amplification returns the number of bytes read from the wrapped io.Reader
+//divided by the number delivered.
+//
+//It is 1 for a plain passthrough. Less than 1 means the stack
+//produces more than it reads, as a decompressor does.
+//More than 1 means it discards or combines bytes.
+//
+//Before anything is read, Amplification returns 1.
+//If bytes have been read but none delivered, it returns +Inf.
+func (a *AmplificationReader) Amplification() float64 {
+	switch {
+	case a.a.delivered > 0:
+		return float64(a.a.read) / float64(a.a.delivered)
+	case a.a.read > 0:
+		return math.Inf(1)
+	}
+	return 1
+}
+
+type amplificationReader struct {
+	r               io.Reader
+	read, delivered int64
+	output          bool //whether delivered is counted by Output
+}
+
+func (a *amplificationReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	a.read += int64(n)
+	if !a.output {
+		a.delivered += int64(n)
+	}
+	return n, err
+}
+
+//deliveredCounter counts the bytes delivered by the reader given to Output.
+type deliveredCounter struct {
+	r io.Reader
+	a *amplificationReader
+}
+
+func (d *deliveredCounter) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	d.a.delivered += int64(n)
+	return n, err
+}
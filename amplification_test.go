@@ -0,0 +1,77 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAmplificationReader(t *testing.T) {
+	a := NewAmplificationReader(strings.NewReader("Hello, World!"))
+	if got := a.Amplification(); got != 1 {
+		t.Errorf("before reading: got %v", got)
+	}
+	if _, err := io.ReadAll(a); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Amplification(); got != 1 {
+		t.Errorf("passthrough: got %v", got)
+	}
+}
+
+//halver delivers every other byte of r.
+type halver struct {
+	r   io.Reader
+	odd bool
+}
+
+func (h *halver) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	k := 0
+	for _, b := range p[:n] {
+		if !h.odd {
+			p[k] = b
+			k++
+		}
+		h.odd = !h.odd
+	}
+	return k, err
+}
+
+func TestAmplificationReaderDiscard(t *testing.T) {
+	in := strings.Repeat("a_", 500)
+	a := NewAmplificationReader(strings.NewReader(in))
+	out := a.Output(&halver{r: iotest.OneByteReader(a)})
+	got, err := io.ReadAll(out)
+	if err != nil || len(got) != 500 {
+		t.Fatalf("got %d bytes, %v", len(got), err)
+	}
+	if got := a.Amplification(); got != 2 {
+		t.Errorf("got %v want 2", got)
+	}
+}
+
+func TestAmplificationReaderExpand(t *testing.T) {
+	a := NewAmplificationReader(bytes.NewReader(gzipped(t, strings.Repeat("x", 10000))))
+	gz, err := NewStrictGzipReader(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(a.Output(gz)); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Amplification(); got >= 1 {
+		t.Errorf("got %v want < 1", got)
+	}
+}
+
+func TestAmplificationReaderNothingDelivered(t *testing.T) {
+	a := NewAmplificationReader(strings.NewReader("   "))
+	io.ReadAll(a.Output(NewTrimReader(a, " ")))
+	if got := a.Amplification(); !math.IsInf(got, 1) {
+		t.Errorf("got %v want +Inf", got)
+	}
+}
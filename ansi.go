@@ -0,0 +1,126 @@
+package simple
+
+import "io"
+
+//NewANSIStripReader returns a Reader that removes ANSI escape sequences
+//from r:
+//CSI sequences (ESC [ ... final byte),
+//OSC sequences (ESC ] ... terminated by BEL or ESC \),
+//and other escape sequences (ESC, any intermediate bytes, final byte).
+//
+//An incomplete sequence at the end of the stream is dropped.
+func NewANSIStripReader(r io.Reader) *Reader {
+	return newTransformReader(r, &ansiTransform{})
+}
+
+//NewANSIColorStripReader is like NewANSIStripReader except that it only
+//removes SGR sequences (ESC [ ... m), which set colors and text attributes,
+//and leaves any other escape sequence in place.
+func NewANSIColorStripReader(r io.Reader) *Reader {
+	return newTransformReader(r, &ansiTransform{colorOnly: true})
+}
+
+const (
+	ansiText = iota
+	ansiEsc
+	ansiCSI
+	ansiOSC
+	ansiOSCEsc
+)
+
+const esc = 0x1b
+
+type ansiTransform struct {
+	colorOnly bool
+	state     int
+	seq       []byte //the sequence so far, if colorOnly
+}
+
+func (a *ansiTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		if a.state != ansiText && a.colorOnly {
+			a.seq = append(a.seq, b)
+		}
+
+		switch a.state {
+		case ansiText:
+			if b == esc {
+				a.state = ansiEsc
+				a.seq = append(a.seq[:0], b)
+			} else {
+				out = append(out, b)
+			}
+
+		case ansiEsc:
+			switch {
+			case b == '[':
+				a.state = ansiCSI
+			case b == ']' && !a.colorOnly:
+				a.state = ansiOSC
+			case a.colorOnly:
+				out = a.keep(out)
+			case b == esc, 0x20 <= b && b <= 0x2f:
+				//restarted, or an intermediate byte before the final byte
+			default:
+				a.state = ansiText
+			}
+
+		case ansiCSI:
+			if 0x40 <= b && b <= 0x7e {
+				if a.colorOnly && !a.isSGR() {
+					out = a.keep(out)
+				}
+				a.state = ansiText
+			}
+
+		case ansiOSC:
+			switch b {
+			case 0x07:
+				a.state = ansiText
+			case esc:
+				a.state = ansiOSCEsc
+			}
+
+		case ansiOSCEsc:
+			if b == '\\' {
+				a.state = ansiText
+			} else {
+				a.state = ansiOSC
+			}
+		}
+	}
+	return out, nil
+}
+
+//keep delivers a sequence that is not being stripped.
+//A trailing ESC begins a new sequence.
+func (a *ansiTransform) keep(out []byte) []byte {
+	a.state = ansiText
+	if last := len(a.seq) - 1; a.seq[last] == esc {
+		out = append(out, a.seq[:last]...)
+		a.seq = append(a.seq[:0], esc)
+		a.state = ansiEsc
+		return out
+	}
+	return append(out, a.seq...)
+}
+
+//isSGR reports whether seq is ESC [ params m
+//with params of only digits and semicolons.
+func (a *ansiTransform) isSGR() bool {
+	if a.seq[len(a.seq)-1] != 'm' {
+		return false
+	}
+	for _, b := range a.seq[2 : len(a.seq)-1] {
+		if b != ';' && (b < '0' || b > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *ansiTransform) flush(out []byte) ([]byte, error) {
+	a.state = ansiText
+	a.seq = a.seq[:0]
+	return out, nil
+}
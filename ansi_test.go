@@ -0,0 +1,32 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestANSIStripReader(t *testing.T) {
+	cases := []struct{ in, all, color string }{
+		{"\x1b[1;31mred\x1b[0m plain", "red plain", "red plain"},
+		{"a\x1b[2Kb\x1b[10;5Hc", "abc", "a\x1b[2Kb\x1b[10;5Hc"},
+		{"\x1b]0;title\x07text\x1b]8;;http://x\x1b\\link", "textlink", "\x1b]0;title\x07text\x1b]8;;http://x\x1b\\link"},
+		{"\x1b(Bx\x1b\x1b[32my", "xy", "\x1b(Bx\x1by"},
+		{"\x1b[?25lhidden", "hidden", "\x1b[?25lhidden"},
+		{"incomplete\x1b[31", "incomplete", "incomplete"},
+		{"incomplete\x1b", "incomplete", "incomplete"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewANSIStripReader(wrap(strings.NewReader(c.in))))
+			if err != nil || string(got) != c.all {
+				t.Errorf("all %q: got %q, %v want %q", c.in, got, err, c.all)
+			}
+			got, err = io.ReadAll(NewANSIColorStripReader(wrap(strings.NewReader(c.in))))
+			if err != nil || string(got) != c.color {
+				t.Errorf("color %q: got %q, %v want %q", c.in, got, err, c.color)
+			}
+		}
+	}
+}
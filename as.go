@@ -0,0 +1,26 @@
+package simple
+
+import "io"
+
+//As returns the first io.Reader of type T wrapped by r,
+//looking through any *Readers wrapping other *Readers,
+//so that As[*os.File](NewReader(NewReader(f))) returns f.
+//
+//Only *Readers are looked through.
+//Readers that transform their input, such as those from NewChunkedReader,
+//do not expose what they read from, so the search stops at them.
+//As can still return such a reader itself, as an io.Reader
+//or an interface it implements.
+func As[T io.Reader](r *Reader) (T, bool) {
+	for {
+		if t, ok := r.r.(T); ok {
+			return t, true
+		}
+		next, ok := r.r.(*Reader)
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		r = next
+	}
+}
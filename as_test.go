@@ -0,0 +1,27 @@
+package simple
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAs(t *testing.T) {
+	sr := strings.NewReader("Hello")
+
+	if got, ok := As[*strings.Reader](NewReader(sr)); !ok || got != sr {
+		t.Errorf("direct: got %v, %v", got, ok)
+	}
+	if got, ok := As[*strings.Reader](NewReader(NewReader(sr))); !ok || got != sr {
+		t.Errorf("double: got %v, %v", got, ok)
+	}
+	if _, ok := As[*os.File](NewReader(NewReader(sr))); ok {
+		t.Error("found an *os.File that is not there")
+	}
+
+	//a wrapped *Reader may itself be sought
+	inner := NewReader(sr)
+	if got, ok := As[*Reader](NewReader(inner)); !ok || got != inner {
+		t.Errorf("*Reader: got %v, %v", got, ok)
+	}
+}
@@ -0,0 +1,47 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+)
+
+//errNoAvailable is returned by Available when there is no way
+//to tell how much can be read.
+var errNoAvailable = fmt.Errorf("simple: cannot determine available bytes: %w", errors.ErrUnsupported)
+
+//Available returns a best-effort hint of how many bytes can be read
+//without blocking.
+//
+//If an error is stored from the last Read, Available returns 0,
+//as the next Read returns that error without reading.
+//
+//Otherwise, the wrapped io.Reader is consulted:
+//a Buffered method, as on *bufio.Reader, or a Len method, as on
+//*bytes.Reader, reports the bytes it holds;
+//a syscall.Conn, such as *os.File or *net.TCPConn, is queried
+//with FIONREAD, on platforms that support it.
+//If none of these apply, it returns an error matching errors.ErrUnsupported.
+//
+//The number is only a hint:
+//more may arrive before the next Read, and a Read may return less.
+func (r *Reader) Available() (int, error) {
+	if r.err != nil {
+		return 0, nil
+	}
+	return available(r.r)
+}
+
+//available implements Available for any io.Reader.
+func available(r io.Reader) (int, error) {
+	switch u := r.(type) {
+	case interface{ Buffered() int }:
+		return u.Buffered(), nil
+	case interface{ Len() int }:
+		return u.Len(), nil
+	case syscall.Conn:
+		return fdAvailable(u)
+	}
+	return 0, errNoAvailable
+}
@@ -0,0 +1,6 @@
+//go:build freebsd || netbsd || dragonfly
+
+package simple
+
+//fionread is _IOR('f', 127, int), which syscall does not define on the BSDs.
+const fionread = 0x4004667f
@@ -0,0 +1,5 @@
+package simple
+
+import "syscall"
+
+const fionread = syscall.TIOCINQ
@@ -0,0 +1,10 @@
+//go:build !linux && !freebsd && !netbsd && !dragonfly
+
+package simple
+
+import "syscall"
+
+//fdAvailable is unsupported on this platform.
+func fdAvailable(syscall.Conn) (int, error) {
+	return 0, errNoAvailable
+}
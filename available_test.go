@@ -0,0 +1,44 @@
+package simple
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReaderAvailable(t *testing.T) {
+	r := NewReader(strings.NewReader("Hello, World!"))
+	if n, err := r.Available(); n != 13 || err != nil {
+		t.Errorf("got %d, %v want 13", n, err)
+	}
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Available(); n != 3 || err != nil {
+		t.Errorf("got %d, %v want 3", n, err)
+	}
+
+	br := bufio.NewReader(strings.NewReader("Hello, World!"))
+	if _, err := br.Peek(5); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := NewReader(br).Available(); n != 13 || err != nil {
+		t.Errorf("bufio: got %d, %v want 13", n, err)
+	}
+
+	//nothing to read until the stored error is returned
+	b := NewBasic("abc")
+	r = NewReader(&b)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Available(); n != 0 || err != nil {
+		t.Errorf("stored error: got %d, %v", n, err)
+	}
+
+	b = NewBasic("abc")
+	if _, err := NewReader(&b).Available(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("got %v want ErrUnsupported", err)
+	}
+}
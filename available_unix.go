@@ -0,0 +1,29 @@
+//go:build linux || freebsd || netbsd || dragonfly
+
+package simple
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+//fdAvailable asks the kernel how many bytes can be read from c.
+func fdAvailable(c syscall.Conn) (int, error) {
+	raw, err := c.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int32
+	var errno syscall.Errno
+	err = raw.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, fionread, uintptr(unsafe.Pointer(&n)))
+	})
+	if err != nil {
+		return 0, err
+	}
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(n), nil
+}
@@ -0,0 +1,28 @@
+//go:build linux || freebsd || netbsd || dragonfly
+
+package simple
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReaderAvailablePipe(t *testing.T) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+	defer pw.Close()
+
+	r := NewReader(pr)
+	if n, err := r.Available(); n != 0 || err != nil {
+		t.Errorf("empty pipe: got %d, %v", n, err)
+	}
+	if _, err := pw.Write([]byte("Hello")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Available(); n != 5 || err != nil {
+		t.Errorf("got %d, %v want 5", n, err)
+	}
+}
@@ -0,0 +1,86 @@
+package simple
+
+import (
+	"encoding/base32"
+	"io"
+)
+
+//Base32Reader is a Reader that decodes base32.
+type Base32Reader struct {
+	*Reader
+	r io.Reader
+}
+
+//NewBase32Reader wraps r in a Base32Reader that decodes the text read from r
+//with enc, as by base32.NewDecoder.
+//
+//Groups of eight characters may be split across reads of r,
+//the last group may be padded or, if enc has no padding, short,
+//and \r and \n are ignored.
+//If enc has no padding, its alphabet must not contain '='.
+//Invalid input results in a base32.CorruptInputError,
+//returned after the data that preceded it.
+//
+//NewBase32Reader panics if enc is nil.
+func NewBase32Reader(r io.Reader, enc *base32.Encoding) *Base32Reader {
+	must(r)
+	if enc == nil {
+		panic("nil base32.Encoding")
+	}
+	src := r
+	//base32.NewDecoder mistakes a short last group for corrupt input
+	//unless it arrives in a single read, so restore the padding
+	if enc.EncodedLen(1) < 8 {
+		enc = enc.WithPadding(base32.StdPadding)
+		src = &base32Padder{r: r}
+	}
+	return &Base32Reader{
+		Reader: NewReader(base32.NewDecoder(enc, src)),
+		r:      r,
+	}
+}
+
+//Close closes the wrapped io.Reader, if it is an io.Closer.
+func (b *Base32Reader) Close() error {
+	if c, ok := b.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+//base32Padder pads unpadded base32 from r to a whole number of groups.
+type base32Padder struct {
+	r   io.Reader
+	n   int //characters read, mod 8
+	pad int //padding left to deliver, once r has ended
+	eof bool
+}
+
+func (b *base32Padder) Read(p []byte) (int, error) {
+	if b.eof {
+		n := copy(p, "======="[:b.pad])
+		b.pad -= n
+		if b.pad == 0 {
+			return n, io.EOF
+		}
+		return n, nil
+	}
+
+	n, err := b.r.Read(p)
+	for _, c := range p[:n] {
+		if c != '\r' && c != '\n' {
+			b.n = (b.n + 1) % 8
+		}
+	}
+	if err == io.EOF {
+		err = nil
+		b.eof = true
+		if b.n != 0 {
+			b.pad = 8 - b.n
+		}
+		if n == 0 {
+			return b.Read(p)
+		}
+	}
+	return n, err
+}
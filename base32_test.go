@@ -0,0 +1,49 @@
+package simple
+
+import (
+	"encoding/base32"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBase32Reader(t *testing.T) {
+	const want = "Hello, World!"
+	for _, enc := range []*base32.Encoding{base32.StdEncoding, base32.HexEncoding, base32.StdEncoding.WithPadding(base32.NoPadding)} {
+		in := enc.EncodeToString([]byte(want))
+		//split within groups of 8, and with line breaks
+		in = in[:5] + "\r\n" + in[5:11] + "\n" + in[11:]
+		for i := 0; i <= len(in); i++ {
+			b := NewBase32Reader(NewScriptedReader([]byte(in), []int{i, 3}), enc)
+			got, err := io.ReadAll(iotest.OneByteReader(b))
+			if err != nil || string(got) != want {
+				t.Errorf("%q split at %d: got %q, %v", in, i, got, err)
+			}
+		}
+	}
+}
+
+func TestBase32ReaderContract(t *testing.T) {
+	//the standard decoder returns data with the error
+	b := NewBase32Reader(strings.NewReader("JBSWY3DP!!!!!!!!"), base32.StdEncoding)
+	p, err := Read(b, make([]byte, 10))
+	if string(p) != "Hello" || err != nil {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	var corrupt base32.CorruptInputError
+	if _, err := b.Read(make([]byte, 10)); !errors.As(err, &corrupt) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestBase32ReaderClose(t *testing.T) {
+	c := &closeCounter{Reader: strings.NewReader("")}
+	if err := NewBase32Reader(c, base32.StdEncoding).Close(); err != nil || c.closes != 1 {
+		t.Errorf("got %v, %d closes", err, c.closes)
+	}
+	if err := NewBase32Reader(strings.NewReader(""), base32.StdEncoding).Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,143 @@
+package simple
+
+import (
+	"io"
+	"sync"
+)
+
+//BoundedReader is a Reader that limits the bytes read
+//but not yet released by the caller.
+type BoundedReader struct {
+	*Reader
+	b *boundedReader
+}
+
+//NewBoundedReader wraps r in a BoundedReader that allows at most
+//maxInFlight bytes to be outstanding:
+//each byte delivered by Read counts against maxInFlight until it is Released.
+//
+//Once maxInFlight bytes are outstanding, Read blocks until
+//some are released or the BoundedReader is closed.
+//This bounds the memory used by a pipeline whose later stages
+//release the bytes as they finish with them.
+//
+//NewBoundedReader panics if maxInFlight < 1.
+func NewBoundedReader(r io.Reader, maxInFlight int) *BoundedReader {
+	must(r)
+	if maxInFlight < 1 {
+		panic("max in flight must be positive")
+	}
+	b := &boundedReader{
+		r:       r,
+		max:     maxInFlight,
+		release: make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	return &BoundedReader{
+		Reader: NewReader(b),
+		b:      b,
+	}
+}
+
+//Release returns n delivered bytes to the BoundedReader,
+//allowing that many more to be read.
+//
+//Release may be called concurrently with Read.
+//It panics if n is negative or more than are outstanding.
+func (b *BoundedReader) Release(n int) {
+	b.b.put(n)
+}
+
+//InFlight returns the number of bytes
+//that have been delivered but not released.
+func (b *BoundedReader) InFlight() int {
+	b.b.mu.Lock()
+	defer b.b.mu.Unlock()
+	return b.b.inFlight
+}
+
+//Close stops the reader.
+//Any Read blocked waiting for bytes to be released returns ErrClosed,
+//as do all later Reads.
+//If the wrapped io.Reader is an io.Closer, it is closed.
+//
+//Close may be called concurrently with Read.
+func (b *BoundedReader) Close() error {
+	return b.b.close()
+}
+
+type boundedReader struct {
+	r   io.Reader
+	max int
+
+	mu       sync.Mutex
+	inFlight int //including bytes reserved by a Read in progress
+
+	release chan struct{} //signaled by put
+	once    sync.Once
+	closed  chan struct{}
+}
+
+//take reserves up to n bytes.
+func (b *boundedReader) take(n int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n = min(n, b.max-b.inFlight)
+	b.inFlight += n
+	return n
+}
+
+//put releases n bytes.
+func (b *boundedReader) put(n int) {
+	b.mu.Lock()
+	if n < 0 || n > b.inFlight {
+		b.mu.Unlock()
+		panic("released more bytes than outstanding")
+	}
+	b.inFlight -= n
+	b.mu.Unlock()
+
+	select {
+	case b.release <- struct{}{}:
+	default:
+	}
+}
+
+func (b *boundedReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		select {
+		case <-b.closed:
+			return 0, ErrClosed
+		default:
+		}
+
+		if k := b.take(len(p)); k > 0 {
+			n, err := b.r.Read(p[:k])
+			if n < k {
+				b.put(k - n)
+			}
+			return n, err
+		}
+
+		select {
+		case <-b.closed:
+			return 0, ErrClosed
+		case <-b.release:
+		}
+	}
+}
+
+func (b *boundedReader) close() error {
+	var err error
+	b.once.Do(func() {
+		close(b.closed)
+		if c, ok := b.r.(io.Closer); ok {
+			err = c.Close()
+		}
+	})
+	return err
+}
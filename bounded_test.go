@@ -0,0 +1,90 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedReader(t *testing.T) {
+	const bound = 100
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	r := NewBoundedReader(bytes.NewReader(data), bound)
+
+	//a reader goroutine hands chunks to several workers that release them
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range chunks {
+				time.Sleep(time.Microsecond)
+				r.Release(n)
+			}
+		}()
+	}
+
+	peak, total := 0, 0
+	p := make([]byte, 37)
+	for {
+		n, err := r.Read(p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f := r.InFlight(); f > peak {
+			peak = f
+		}
+		total += n
+		chunks <- n
+	}
+	close(chunks)
+	wg.Wait()
+
+	if total != len(data) {
+		t.Errorf("read %d bytes, want %d", total, len(data))
+	}
+	if peak > bound {
+		t.Errorf("%d bytes in flight, bound is %d", peak, bound)
+	}
+	if f := r.InFlight(); f != 0 {
+		t.Errorf("%d bytes in flight at the end", f)
+	}
+}
+
+func TestBoundedReaderClose(t *testing.T) {
+	r := NewBoundedReader(bytes.NewReader(make([]byte, 100)), 10)
+	if n, err := r.Read(make([]byte, 20)); n != 10 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := r.Read(make([]byte, 20))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Read did not block: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	r.Close()
+	if err := <-done; err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
+
+func TestBoundedReaderReleasePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic")
+		}
+	}()
+	NewBoundedReader(bytes.NewReader(nil), 10).Release(1)
+}
@@ -0,0 +1,33 @@
+package simple
+
+import (
+	"errors"
+	"io"
+)
+
+//ErrTooManyReads is returned by a Reader from NewCallLimitedReader
+//once it has used up its reads.
+var ErrTooManyReads = errors.New("simple: too many reads")
+
+//NewCallLimitedReader returns a Reader that calls r.Read at most maxCalls
+//times and returns ErrTooManyReads instead of calling it again.
+//
+//Returning an error stored from a previous read does not call r.Read,
+//so it does not count toward maxCalls.
+func NewCallLimitedReader(r io.Reader, maxCalls int) *Reader {
+	must(r)
+	return NewReader(&callLimitedReader{r: r, left: maxCalls})
+}
+
+type callLimitedReader struct {
+	r    io.Reader
+	left int
+}
+
+func (c *callLimitedReader) Read(p []byte) (int, error) {
+	if c.left <= 0 {
+		return 0, ErrTooManyReads
+	}
+	c.left--
+	return c.r.Read(p)
+}
@@ -0,0 +1,37 @@
+package simple
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCallLimitedReader(t *testing.T) {
+	r := NewCallLimitedReader(iotest.OneByteReader(strings.NewReader("abcdef")), 3)
+	p := make([]byte, 10)
+	for i := 0; i < 3; i++ {
+		if n, err := r.Read(p); n != 1 || err != nil {
+			t.Fatalf("read %d: got %d, %v", i, n, err)
+		}
+	}
+	if n, err := r.Read(p); n != 0 || err != ErrTooManyReads {
+		t.Errorf("got %d, %v want ErrTooManyReads", n, err)
+	}
+}
+
+func TestCallLimitedReaderDeferred(t *testing.T) {
+	//the first call returns data and an error,
+	//the error is returned without another call,
+	//and only then is the limit reached
+	r := NewCallLimitedReader(&dataErr{"abc", errTruncated}, 1)
+	p := make([]byte, 10)
+	if n, err := r.Read(p); n != 3 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if _, err := r.Read(p); err != errTruncated {
+		t.Fatalf("got %v want %v", err, errTruncated)
+	}
+	if _, err := r.Read(p); err != ErrTooManyReads {
+		t.Errorf("got %v want ErrTooManyReads", err)
+	}
+}
@@ -0,0 +1,94 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"time"
+)
+
+//ErrChaos is the transient error injected by a Reader from NewChaosReader
+//unless ChaosConfig.Err is set.
+var ErrChaos = errors.New("simple: injected error")
+
+//ChaosConfig configures a Reader from NewChaosReader.
+//
+//Each probability is the chance, from 0 to 1, that a Read does that thing.
+type ChaosConfig struct {
+	//Seed seeds the random choices, so that the same Seed
+	//makes the same choices for the same sequence of calls.
+	Seed int64
+
+	//Latency is the probability that a Read first sleeps
+	//for a random duration up to MaxLatency.
+	Latency    float64
+	MaxLatency time.Duration
+
+	//ZeroRead is the probability that a Read returns 0, nil
+	//without reading.
+	ZeroRead float64
+	//Error is the probability that a Read returns Err
+	//without reading.
+	Error float64
+	//Err is the error injected.
+	//The default is ErrChaos.
+	Err error
+	//ShortRead is the probability that a Read reads into
+	//a randomly shortened buffer.
+	ShortRead float64
+}
+
+//NewChaosReader returns a Reader that randomly misbehaves
+//in ways the io.Reader contract allows,
+//to test that the code reading from it copes.
+//
+//Each Read of a non-empty buffer may sleep, then either returns 0, nil,
+//returns an error, or reads, possibly into a shortened buffer,
+//according to the probabilities in cfg.
+//An injected error does not consume any data,
+//so a caller that retries sees the whole stream.
+//
+//It combines the behavior of several simpler readers,
+//like the iotest readers and NewScriptedReader,
+//but a failing test can be reproduced by reusing the Seed.
+func NewChaosReader(r io.Reader, cfg ChaosConfig) *Reader {
+	must(r)
+	if cfg.Err == nil {
+		cfg.Err = ErrChaos
+	}
+	return NewReader(&chaosReader{
+		r:   r,
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(cfg.Seed)),
+	})
+}
+
+type chaosReader struct {
+	r   io.Reader
+	cfg ChaosConfig
+	rnd *rand.Rand
+}
+
+//chance reports whether an event with probability p happens.
+func (c *chaosReader) chance(p float64) bool {
+	return p > 0 && c.rnd.Float64() < p
+}
+
+func (c *chaosReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return c.r.Read(p)
+	}
+
+	if c.chance(c.cfg.Latency) && c.cfg.MaxLatency > 0 {
+		time.Sleep(time.Duration(c.rnd.Int63n(int64(c.cfg.MaxLatency))))
+	}
+	switch {
+	case c.chance(c.cfg.ZeroRead):
+		return 0, nil
+	case c.chance(c.cfg.Error):
+		return 0, c.cfg.Err
+	case c.chance(c.cfg.ShortRead):
+		p = p[:1+c.rnd.Intn(len(p))]
+	}
+	return c.r.Read(p)
+}
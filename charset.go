@@ -0,0 +1,72 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrDisallowedByte is matched, via errors.Is, by the *DisallowedByteError
+//returned by a Reader from NewCharsetReader.
+var ErrDisallowedByte = errors.New("simple: disallowed byte")
+
+//DisallowedByteError reports a byte that failed the predicate
+//of a Reader from NewCharsetReader.
+type DisallowedByteError struct {
+	Byte   byte
+	Offset int64
+}
+
+func (e *DisallowedByteError) Error() string {
+	return fmt.Sprintf("%s %q at offset %d", ErrDisallowedByte, e.Byte, e.Offset)
+}
+
+//Unwrap returns ErrDisallowedByte.
+func (e *DisallowedByteError) Unwrap() error {
+	return ErrDisallowedByte
+}
+
+//NewCharsetReader returns a Reader that delivers the bytes of r
+//up to the first byte for which allowed returns false,
+//then returns a *DisallowedByteError.
+//
+//Each read is checked as it arrives,
+//so invalid input is rejected without reading it all first.
+func NewCharsetReader(r io.Reader, allowed func(byte) bool) *Reader {
+	must(r)
+	return NewReader(&charsetReader{r: r, allowed: allowed})
+}
+
+//IsHexDigit reports whether b is 0-9, a-f, or A-F.
+func IsHexDigit(b byte) bool {
+	return '0' <= b && b <= '9' || 'a' <= b && b <= 'f' || 'A' <= b && b <= 'F'
+}
+
+//IsBase64URL reports whether b is in the URL and filename safe
+//base64 alphabet of RFC 4648, not including the padding byte =.
+func IsBase64URL(b byte) bool {
+	return 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || b == '-' || b == '_'
+}
+
+type charsetReader struct {
+	r       io.Reader
+	allowed func(byte) bool
+	off     int64
+	err     error
+}
+
+func (c *charsetReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	n, err := c.r.Read(p)
+	for i, b := range p[:n] {
+		if !c.allowed(b) {
+			c.err = &DisallowedByteError{Byte: b, Offset: c.off + int64(i)}
+			return i, c.err
+		}
+	}
+	c.off += int64(n)
+	return n, err
+}
@@ -0,0 +1,35 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCharsetReader(t *testing.T) {
+	const in = "0123456789abcdefABCDEF"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewCharsetReader(wrap(strings.NewReader(in)), IsHexDigit))
+		if err != nil || string(got) != in {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+}
+
+func TestCharsetReaderDisallowed(t *testing.T) {
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewCharsetReader(wrap(strings.NewReader("abc-DEF_12+3/4")), IsBase64URL))
+		if string(got) != "abc-DEF_12" {
+			t.Errorf("got %q", got)
+		}
+		var de *DisallowedByteError
+		if !errors.Is(err, ErrDisallowedByte) || !errors.As(err, &de) {
+			t.Fatalf("got %v want ErrDisallowedByte", err)
+		}
+		if de.Byte != '+' || de.Offset != 10 {
+			t.Errorf("got %q at %d want '+' at 10", de.Byte, de.Offset)
+		}
+	}
+}
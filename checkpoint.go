@@ -0,0 +1,83 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+//ErrBadCheckpoint is returned by Resume for a token
+//that was not made by Checkpoint.
+var ErrBadCheckpoint = errors.New("simple: bad checkpoint token")
+
+//errNotResumable is returned by Checkpoint and Resume when the wrapped
+//io.Reader is neither an io.Seeker nor an io.ReaderAt.
+var errNotResumable = fmt.Errorf("simple: wrapped reader cannot be resumed: %w", errors.ErrUnsupported)
+
+//checkpointV1 is the version of a token holding
+//a big-endian 64-bit offset.
+const checkpointV1 = 1
+
+//Checkpoint returns an opaque token recording the current offset,
+//as reported by Tell, so that a later Resume, possibly by another process
+//on another Reader of the same data, can continue from it.
+//
+//The wrapped io.Reader must be an io.Seeker or an io.ReaderAt;
+//otherwise, Checkpoint returns an error matching errors.ErrUnsupported.
+//
+//Any stored error is not part of the checkpoint.
+func (r *Reader) Checkpoint() ([]byte, error) {
+	if !r.resumable() {
+		return nil, errNotResumable
+	}
+	off, err := r.Tell()
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64([]byte{checkpointV1}, uint64(off)), nil
+}
+
+//Resume moves r to the offset recorded in a token from Checkpoint
+//and discards any stored error.
+//Done reports false after a successful Resume.
+//
+//If the wrapped io.Reader is an io.Seeker, it is seeked to the offset.
+//Otherwise, if it is an io.ReaderAt, the Reader continues by reading from it
+//through an *io.SectionReader,
+//which then becomes the wrapped io.Reader as far as As is concerned.
+//Otherwise, Resume returns an error matching errors.ErrUnsupported.
+func (r *Reader) Resume(token []byte) error {
+	if !r.resumable() {
+		return errNotResumable
+	}
+	if len(token) != 9 || token[0] != checkpointV1 {
+		return ErrBadCheckpoint
+	}
+	off := binary.BigEndian.Uint64(token[1:])
+	if off > math.MaxInt64 {
+		return ErrBadCheckpoint
+	}
+
+	s, ok := r.r.(io.Seeker)
+	if !ok {
+		s = io.NewSectionReader(r.r.(io.ReaderAt), 0, math.MaxInt64)
+		r.r = s.(io.Reader)
+	}
+	if _, err := s.Seek(int64(off), io.SeekStart); err != nil {
+		return err
+	}
+	r.err = nil
+	r.off = int64(off)
+	r.done = false
+	return nil
+}
+
+func (r *Reader) resumable() bool {
+	switch r.r.(type) {
+	case io.Seeker, io.ReaderAt:
+		return true
+	}
+	return false
+}
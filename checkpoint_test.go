@@ -0,0 +1,68 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+//readerAtOnly hides every method but ReadAt.
+type readerAtOnly struct {
+	io.ReaderAt
+	off int64
+}
+
+func (r *readerAtOnly) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.off)
+	r.off += int64(n)
+	return n, err
+}
+
+func TestReaderCheckpoint(t *testing.T) {
+	const in = "Hello, World!"
+	sources := map[string]func() io.Reader{
+		"seeker":   func() io.Reader { return strings.NewReader(in) },
+		"readerat": func() io.Reader { return &readerAtOnly{ReaderAt: strings.NewReader(in)} },
+	}
+	for name, src := range sources {
+		r := NewReader(src())
+		if _, err := io.ReadFull(r, make([]byte, 7)); err != nil {
+			t.Fatal(err)
+		}
+		token, err := r.Checkpoint()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		fresh := NewReader(src())
+		if err := fresh.Resume(token); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := io.ReadAll(fresh)
+		if err != nil || string(got) != "World!" {
+			t.Errorf("%s: got %q, %v", name, got, err)
+		}
+		if off, _ := fresh.Tell(); off != int64(len(in)) {
+			t.Errorf("%s: at %d after resume, want %d", name, off, len(in))
+		}
+	}
+}
+
+func TestReaderCheckpointErrors(t *testing.T) {
+	b := NewBasic("Hello")
+	r := NewReader(&b)
+	if _, err := r.Checkpoint(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Checkpoint: got %v", err)
+	}
+	if err := r.Resume([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Resume: got %v", err)
+	}
+
+	r = NewReader(strings.NewReader("Hello"))
+	for _, token := range [][]byte{nil, {2, 0, 0, 0, 0, 0, 0, 0, 0}, {1, 0}} {
+		if err := r.Resume(token); err != ErrBadCheckpoint {
+			t.Errorf("%v: got %v want ErrBadCheckpoint", token, err)
+		}
+	}
+}
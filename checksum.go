@@ -0,0 +1,114 @@
+package simple
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+var (
+	//ErrChecksumMismatch is matched, via errors.Is, by the *ChecksumError
+	//returned by a Reader from NewChecksummedReader.
+	ErrChecksumMismatch = errors.New("simple: checksum mismatch")
+	//ErrUnknownAlgorithm is returned, wrapped with more detail,
+	//by NewChecksummedReader for an algorithm it does not support.
+	ErrUnknownAlgorithm = errors.New("simple: unknown hash algorithm")
+)
+
+//ChecksumError reports that a stream did not have the expected checksum.
+type ChecksumError struct {
+	Algorithm string
+	//Got and Want are hex encoded.
+	Got, Want string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s: %s %s want %s", ErrChecksumMismatch, e.Algorithm, e.Got, e.Want)
+}
+
+//Unwrap returns ErrChecksumMismatch.
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
+//checksums are the algorithms supported by NewChecksummedReader.
+var checksums = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"md5":    md5.New,
+	"crc32":  func() hash.Hash { return crc32.NewIEEE() },
+}
+
+//NewChecksummedReader returns a Reader that delivers r unchanged
+//while hashing it with algo, "sha256", "md5", or "crc32" (IEEE),
+//and at the end of r compares the digest with expected, in hex.
+//If they differ, the Reader returns a *ChecksumError in place of io.EOF.
+//
+//The data is delivered before it can be verified,
+//so nothing read should be trusted until the Reader returns io.EOF.
+//
+//NewChecksummedReader returns an error wrapping ErrUnknownAlgorithm
+//for any other algo, or an error if expected is not the hex encoding
+//of a digest of the right size.
+func NewChecksummedReader(r io.Reader, algo string, expected string) (*Reader, error) {
+	must(r)
+	newHash, ok := checksums[algo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, algo)
+	}
+	h := newHash()
+
+	want, err := hex.DecodeString(expected)
+	if err != nil {
+		return nil, fmt.Errorf("simple: expected %s checksum: %w", algo, err)
+	}
+	if len(want) != h.Size() {
+		return nil, fmt.Errorf("simple: expected %s checksum is %d bytes, not %d", algo, len(want), h.Size())
+	}
+
+	return NewReader(&checksumReader{
+		r:    r,
+		algo: algo,
+		h:    h,
+		want: want,
+	}), nil
+}
+
+type checksumReader struct {
+	r    io.Reader
+	algo string
+	h    hash.Hash
+	want []byte
+	err  error //the result of verifying, once r has ended
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	n, err := c.r.Read(p)
+	c.h.Write(p[:n])
+	if err == io.EOF {
+		err = c.verify()
+		c.err = err
+	}
+	return n, err
+}
+
+func (c *checksumReader) verify() error {
+	got := c.h.Sum(nil)
+	if bytes.Equal(got, c.want) {
+		return io.EOF
+	}
+	return &ChecksumError{
+		Algorithm: c.algo,
+		Got:       hex.EncodeToString(got),
+		Want:      hex.EncodeToString(c.want),
+	}
+}
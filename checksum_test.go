@@ -0,0 +1,57 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestChecksummedReader(t *testing.T) {
+	const in = "Hello, World!"
+	digests := map[string]string{
+		"sha256": "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f",
+		"md5":    "65A8E27D8879283831B664BD8B7F0AD4",
+		"crc32":  "ec4ac3d0",
+	}
+	for algo, sum := range digests {
+		r, err := NewChecksummedReader(iotest.HalfReader(strings.NewReader(in)), algo, sum)
+		if err != nil {
+			t.Fatalf("%s: %v", algo, err)
+		}
+		if got, err := io.ReadAll(r); err != nil || string(got) != in {
+			t.Errorf("%s: got %q, %v", algo, got, err)
+		}
+
+		//the same digest of different data
+		r, err = NewChecksummedReader(strings.NewReader(in+"\n"), algo, sum)
+		if err != nil {
+			t.Fatalf("%s: %v", algo, err)
+		}
+		got, err := io.ReadAll(r)
+		var ce *ChecksumError
+		if string(got) != in+"\n" || !errors.As(err, &ce) || !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("%s: got %q, %v", algo, got, err)
+		}
+		if ce.Algorithm != algo || !strings.EqualFold(ce.Want, sum) || ce.Got == ce.Want {
+			t.Errorf("%s: got %+v", algo, ce)
+		}
+		if _, err2 := r.Read(make([]byte, 1)); err2 != err {
+			t.Errorf("%s: got %v want %v", algo, err2, err)
+		}
+	}
+}
+
+func TestChecksummedReaderBadConfig(t *testing.T) {
+	r := strings.NewReader("")
+	if _, err := NewChecksummedReader(r, "sha1", "da39a3ee5e6b4b0d3255bfef95601890afd80709"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("got %v", err)
+	}
+	if _, err := NewChecksummedReader(r, "crc32", "not hex!"); err == nil {
+		t.Error("accepted bad hex")
+	}
+	if _, err := NewChecksummedReader(r, "md5", "ec4ac3d0"); err == nil {
+		t.Error("accepted short digest")
+	}
+}
@@ -0,0 +1,155 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//ErrMalformedChunk is returned, possibly wrapped with more detail,
+//by a Reader from NewChunkedReader when the underlying stream
+//is not valid chunked transfer encoding.
+var ErrMalformedChunk = errors.New("simple: malformed chunked encoding")
+
+//NewChunkedReader returns a Reader that decodes the HTTP/1.1 chunked
+//transfer encoding read from r.
+//
+//Chunk extensions and trailers are read and discarded.
+//Trailers totaling more than 64KiB are rejected as malformed.
+//The Reader returns io.EOF after the terminating zero-length chunk
+//and its trailers, or io.ErrUnexpectedEOF if r ends before then.
+//
+//If r is not an io.ByteReader, it is buffered,
+//so more may be read from r than the encoded body.
+func NewChunkedReader(r io.Reader) *Reader {
+	must(r)
+	br, ok := r.(chunkedSource)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return NewReader(&chunkedReader{r: br})
+}
+
+type chunkedSource interface {
+	io.Reader
+	io.ByteReader
+}
+
+type chunkedReader struct {
+	r    chunkedSource
+	left uint64 //bytes remaining in the current chunk
+	crlf bool   //whether the current chunk's data still needs its CRLF
+	err  error
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if c.left == 0 {
+		if c.err = c.next(); c.err != nil {
+			return 0, c.err
+		}
+	}
+
+	if uint64(len(p)) > c.left {
+		p = p[:c.left]
+	}
+	n, err := c.r.Read(p)
+	c.left -= uint64(n)
+	if c.left == 0 {
+		c.crlf = true
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	c.err = err
+	return n, err
+}
+
+//next reads up to the start of the next chunk's data.
+//It returns io.EOF after reading the last chunk and trailers.
+func (c *chunkedReader) next() error {
+	if c.crlf {
+		line, err := c.line()
+		if err != nil {
+			return err
+		}
+		if len(line) != 0 {
+			return fmt.Errorf("%w: chunk data followed by %q, not CRLF", ErrMalformedChunk, line)
+		}
+		c.crlf = false
+	}
+
+	line, err := c.line()
+	if err != nil {
+		return err
+	}
+	if i := bytes.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	line = bytes.TrimRight(line, " \t")
+	if len(line) == 0 {
+		return fmt.Errorf("%w: bad chunk size %q", ErrMalformedChunk, line)
+	}
+	size, err := strconv.ParseUint(string(line), 16, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad chunk size %q", ErrMalformedChunk, line)
+	}
+
+	if size == 0 {
+		//skip trailers up to and including the empty line
+		for total := 0; ; {
+			line, err := c.line()
+			if err != nil {
+				return err
+			}
+			if len(line) == 0 {
+				return io.EOF
+			}
+			if total += len(line) + 2; total > maxChunkTrailer {
+				return fmt.Errorf("%w: trailers too long", ErrMalformedChunk)
+			}
+		}
+	}
+
+	c.left = size
+	return nil
+}
+
+//maxChunkLine bounds the length of chunk size and trailer lines.
+const maxChunkLine = 4096
+
+//maxChunkTrailer bounds the total length of the trailers.
+const maxChunkTrailer = 64 << 10
+
+//line returns the next CRLF terminated line without the CRLF.
+func (c *chunkedReader) line() ([]byte, error) {
+	var line []byte
+	for {
+		b, err := c.r.ReadByte()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b == '\n' {
+			if len(line) == 0 || line[len(line)-1] != '\r' {
+				return nil, fmt.Errorf("%w: line not terminated by CRLF", ErrMalformedChunk)
+			}
+			return line[:len(line)-1], nil
+		}
+		if len(line) == maxChunkLine {
+			return nil, fmt.Errorf("%w: line too long", ErrMalformedChunk)
+		}
+		line = append(line, b)
+	}
+}
@@ -0,0 +1,52 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestChunkedReader(t *testing.T) {
+	const in = "5\r\nHello\r\n" +
+		"2;ext=1\r\n, \r\n" +
+		"1A \r\nabcdefghijklmnopqrstuvwxyz\r\n" +
+		"00000000000000001\r\n!\r\n" +
+		"0\r\nTrailer: yes\r\n\r\n"
+	const want = "Hello, abcdefghijklmnopqrstuvwxyz!"
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewChunkedReader(wrap(strings.NewReader(in))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("got %q want %q", got, want)
+		}
+	}
+}
+
+func TestChunkedReaderMalformed(t *testing.T) {
+	cases := []struct {
+		in   string
+		want error
+	}{
+		{"x\r\nabc\r\n0\r\n\r\n", ErrMalformedChunk},
+		{"\r\n", ErrMalformedChunk},
+		{"3\nabc\r\n0\r\n\r\n", ErrMalformedChunk},
+		{"3\r\nabcd\r\n0\r\n\r\n", ErrMalformedChunk},
+		{"11111111111111111\r\n", ErrMalformedChunk},
+		{"3\r\nabc\r\n0\r\nTrailer: no CRLF\n\r\n", ErrMalformedChunk},
+		{"0\r\n" + strings.Repeat("Trailer: yes\r\n", maxChunkTrailer/14+1) + "\r\n", ErrMalformedChunk},
+		{"3\r\nab", io.ErrUnexpectedEOF},
+		{"3\r\nabc\r\n0\r\n", io.ErrUnexpectedEOF},
+		{"", io.ErrUnexpectedEOF},
+	}
+	for _, c := range cases {
+		_, err := io.ReadAll(NewChunkedReader(strings.NewReader(c.in)))
+		if !errors.Is(err, c.want) {
+			t.Errorf("%q: got %v want %v", c.in, err, c.want)
+		}
+	}
+}
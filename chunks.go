@@ -0,0 +1,66 @@
+package simple
+
+import (
+	"iter"
+	"math/bits"
+)
+
+//chunkWindow is the number of bytes ContentChunks hashes
+//to find boundaries.
+const chunkWindow = 64
+
+//ContentChunks returns an iterator over the rest of the stream
+//split into chunks at content defined boundaries,
+//so that the same content is split in the same places
+//regardless of what precedes it or how it is read.
+//
+//A boundary is placed after any byte at which
+//the rolling checksum of the last 64 bytes, as by RollingHashReader,
+//hits a mask chosen so that chunks average about avgSize bytes.
+//Chunks are at least avgSize/4 and at most 4*avgSize bytes.
+//
+//Each chunk is newly allocated and yielded with a nil error.
+//The last pair yielded has a nil chunk and either io.EOF,
+//if the stream ended cleanly, or the error that stopped it,
+//after a final chunk of any bytes read before it.
+//
+//ContentChunks panics if avgSize < 1.
+func (r *Reader) ContentChunks(avgSize int) iter.Seq2[[]byte, error] {
+	if avgSize < 1 {
+		panic("average chunk size must be positive")
+	}
+	least, most := max(avgSize/4, 1), 4*avgSize
+	shift := 32 - (bits.Len(uint(avgSize)) - 1)
+
+	return func(yield func([]byte, error) bool) {
+		h := newRollingHash(chunkWindow)
+		buf := r.allocBuf(32 << 10)
+		defer r.freeBuf(buf)
+		var chunk []byte
+		for {
+			n, err := r.Read(buf)
+			for _, b := range buf[:n] {
+				h.roll(b)
+				chunk = append(chunk, b)
+				if len(chunk) < least {
+					continue
+				}
+				//mix the checksum so the boundary test uses all its bits
+				if mixed := h.sum() * 0x9e3779b1; uint64(mixed)>>shift == 0 || len(chunk) == most {
+					if !yield(chunk, nil) {
+						return
+					}
+					chunk = nil
+				}
+			}
+
+			if err != nil {
+				if len(chunk) > 0 && !yield(chunk, nil) {
+					return
+				}
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func chunkSizes(t *testing.T, r io.Reader, avg int) []int {
+	t.Helper()
+	var sizes []int
+	for chunk, err := range NewReader(r).ContentChunks(avg) {
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		sizes = append(sizes, len(chunk))
+	}
+	return sizes
+}
+
+func TestContentChunks(t *testing.T) {
+	data := make([]byte, 200<<10)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	const avg = 1024
+	want := chunkSizes(t, bytes.NewReader(data), avg)
+	total := 0
+	for _, n := range want {
+		if n < avg/4 && total+n != len(data) || n > 4*avg {
+			t.Errorf("chunk of %d bytes", n)
+		}
+		total += n
+	}
+	if total != len(data) {
+		t.Fatalf("chunks total %d bytes, want %d", total, len(data))
+	}
+	if mean := len(data) / len(want); mean < avg/2 || mean > 2*avg {
+		t.Errorf("mean chunk size %d, want about %d", mean, avg)
+	}
+
+	readers := map[string]io.Reader{
+		"onebyte":  iotest.OneByteReader(bytes.NewReader(data)),
+		"half":     iotest.HalfReader(bytes.NewReader(data)),
+		"scripted": NewScriptedReader(data, []int{1, 100, 7, 5000}),
+	}
+	for name, r := range readers {
+		got := chunkSizes(t, r, avg)
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d chunks, want %d", name, len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s: chunk %d is %d bytes, want %d", name, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestContentChunksResync(t *testing.T) {
+	//an insertion only changes the chunks around it
+	data := make([]byte, 100<<10)
+	rand.New(rand.NewSource(2)).Read(data)
+	edited := append(append(append([]byte(nil), data[:5000]...), "inserted"...), data[5000:]...)
+
+	chunks := func(b []byte) map[string]bool {
+		m := map[string]bool{}
+		for chunk, err := range NewReader(bytes.NewReader(b)).ContentChunks(1024) {
+			if err != nil {
+				break
+			}
+			m[string(chunk)] = true
+		}
+		return m
+	}
+	before, after := chunks(data), chunks(edited)
+	shared := 0
+	for c := range before {
+		if after[c] {
+			shared++
+		}
+	}
+	if shared < len(before)-3 {
+		t.Errorf("only %d of %d chunks survived an insertion", shared, len(before))
+	}
+}
+
+func TestContentChunksError(t *testing.T) {
+	var got []string
+	for chunk, err := range NewReader(&dataErr{"abc", errTruncated}).ContentChunks(1024) {
+		if err != nil {
+			got = append(got, err.Error())
+			break
+		}
+		got = append(got, string(chunk))
+	}
+	if len(got) != 2 || got[0] != "abc" || got[1] != "truncated" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,96 @@
+package simple
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+//Codec decodes messages from a framed stream.
+//
+//Decode reads one message from src and returns its payload,
+//without any framing.
+//
+//Decode returns io.EOF, and no message, if src ends cleanly
+//before a message begins.
+//If src ends within a message, it returns io.ErrUnexpectedEOF.
+//Any other error means the framing is broken,
+//and is returned by the Reader after any message returned with it.
+type Codec interface {
+	Decode(src *Reader) ([]byte, error)
+}
+
+//NewCodecReader returns a Reader that delivers the payloads of the successive
+//messages c decodes from r.
+//
+//Each Read delivers data from at most one message,
+//so a buffer large enough for any message receives one message per Read.
+//Empty messages are skipped.
+//
+//r is buffered, so more may be read from r than the messages decoded.
+func NewCodecReader(r io.Reader, c Codec) *Reader {
+	must(r)
+	return NewReader(&codecReader{
+		src: NewReader(bufio.NewReader(r)),
+		c:   c,
+	})
+}
+
+type codecReader struct {
+	src *Reader
+	c   Codec
+	msg []byte //undelivered part of the current message
+	err error
+}
+
+func (c *codecReader) Read(p []byte) (int, error) {
+	for len(c.msg) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		c.msg, c.err = c.c.Decode(c.src)
+	}
+	n := copy(p, c.msg)
+	c.msg = c.msg[n:]
+	return n, nil
+}
+
+//LineCodec is a Codec for newline delimited messages.
+//
+//The \n or \r\n ending each message is removed.
+//A last line without a line ending is a message.
+type LineCodec struct{}
+
+//Decode reads one line from src.
+func (LineCodec) Decode(src *Reader) ([]byte, error) {
+	br := &byteReader{r: src}
+	var line []byte
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF && len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b == '\n' {
+			if n := len(line); n > 0 && line[n-1] == '\r' {
+				line = line[:n-1]
+			}
+			return line, nil
+		}
+		line = append(line, b)
+	}
+}
+
+//LengthPrefixCodec is a Codec for messages prefixed by their length,
+//as read by ReadFrame.
+type LengthPrefixCodec struct {
+	Order       binary.ByteOrder
+	PrefixBytes int //1, 2, 4, or 8
+}
+
+//Decode reads one frame from src.
+func (l LengthPrefixCodec) Decode(src *Reader) ([]byte, error) {
+	return src.ReadFrame(l.Order, l.PrefixBytes)
+}
@@ -0,0 +1,96 @@
+package simple
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//messages reads every message from r, one per Read.
+func messages(r io.Reader) ([]string, error) {
+	var msgs []string
+	p := make([]byte, 64)
+	for {
+		n, err := r.Read(p)
+		if n > 0 {
+			msgs = append(msgs, string(p[:n]))
+		}
+		if err == io.EOF {
+			return msgs, nil
+		}
+		if err != nil {
+			return msgs, err
+		}
+	}
+}
+
+func TestCodecReader(t *testing.T) {
+	cases := []struct {
+		name string
+		c    Codec
+		in   string
+	}{
+		{"line", LineCodec{}, "one\r\ntwo\n\nthree"},
+		{"length", LengthPrefixCodec{binary.BigEndian, 2}, "\x00\x03one\x00\x03two\x00\x00\x00\x05three"},
+		{"netstring", NetstringCodec{}, "3:one,3:two,0:,5:three,"},
+	}
+	want := "[one two three]"
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := messages(NewCodecReader(wrap(strings.NewReader(c.in)), c.c))
+			if err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+			if s := "[" + strings.Join(got, " ") + "]"; s != want {
+				t.Errorf("%s: got %s want %s", c.name, s, want)
+			}
+		}
+	}
+}
+
+func TestCodecReaderManyEmpty(t *testing.T) {
+	//more empty messages in a row than bufio.Reader allows reads without progress
+	in := "one\n" + strings.Repeat("\n", 200) + "two\n"
+	br := bufio.NewReader(NewCodecReader(strings.NewReader(in), LineCodec{}))
+	got, err := br.ReadString('!')
+	if err != io.EOF || got != "onetwo" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestCodecReaderSmallBuffer(t *testing.T) {
+	r := NewCodecReader(strings.NewReader("11:hello world,"), NetstringCodec{})
+	got, err := io.ReadAll(iotest.OneByteReader(r))
+	if err != nil || string(got) != "hello world" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestCodecReaderMalformed(t *testing.T) {
+	cases := []struct {
+		name string
+		c    Codec
+		in   string
+		want error
+	}{
+		{"netstring no comma", NetstringCodec{}, "3:one;", ErrMalformedNetstring},
+		{"netstring bad length", NetstringCodec{}, "x:one,", ErrMalformedNetstring},
+		{"netstring leading zero", NetstringCodec{}, "03:one,", ErrMalformedNetstring},
+		{"netstring truncated", NetstringCodec{}, "3:one,5:th", io.ErrUnexpectedEOF},
+		{"netstring truncated length", NetstringCodec{}, "3:one,5", io.ErrUnexpectedEOF},
+		{"length truncated", LengthPrefixCodec{binary.BigEndian, 4}, "\x00\x00\x00\x05thr", io.ErrUnexpectedEOF},
+	}
+	for _, c := range cases {
+		msgs, err := messages(NewCodecReader(strings.NewReader(c.in), c.c))
+		if !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v want %v", c.name, err, c.want)
+		}
+		if strings.HasPrefix(c.in, "3:one,") && (len(msgs) != 1 || msgs[0] != "one") {
+			t.Errorf("%s: got messages %q before the error", c.name, msgs)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package simple
+
+import "io"
+
+//NewWhitespaceCollapseReader returns a Reader that replaces each run
+//of ASCII whitespace in r with a single space.
+//
+//Whitespace is space, \t, \n, \v, \f, and \r.
+//Runs may span any number of reads of r.
+func NewWhitespaceCollapseReader(r io.Reader) *Reader {
+	return newTransformReader(r, &collapseTransform{})
+}
+
+//NewWhitespaceCollapseTrimReader is like NewWhitespaceCollapseReader
+//except that whitespace at the start and end of the stream is removed
+//rather than collapsed.
+func NewWhitespaceCollapseTrimReader(r io.Reader) *Reader {
+	return newTransformReader(r, &collapseTransform{trim: true, start: true})
+}
+
+type collapseTransform struct {
+	trim  bool
+	start bool //whether nothing but whitespace has been seen
+	space bool //whether the last byte seen was whitespace
+}
+
+func isASCIISpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+func (c *collapseTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		if isASCIISpace(b) {
+			//without trim the space is emitted at the start of the run,
+			//with trim once it is known not to end the stream
+			if !c.space && !c.trim {
+				out = append(out, ' ')
+			}
+			c.space = true
+			continue
+		}
+		if c.space && c.trim && !c.start {
+			out = append(out, ' ')
+		}
+		c.space, c.start = false, false
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func (c *collapseTransform) flush(out []byte) ([]byte, error) {
+	return out, nil
+}
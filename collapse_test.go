@@ -0,0 +1,37 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWhitespaceCollapseReader(t *testing.T) {
+	cases := []struct{ in, collapse, trim string }{
+		{" \t one  \r\n two\v\f\tthree \n", " one two three ", "one two three"},
+		{"one", "one", "one"},
+		{" \n ", " ", ""},
+		{"", "", ""},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewWhitespaceCollapseReader(wrap(strings.NewReader(c.in))))
+			if err != nil || string(got) != c.collapse {
+				t.Errorf("%q: got %q, %v want %q", c.in, got, err, c.collapse)
+			}
+			got, err = io.ReadAll(NewWhitespaceCollapseTrimReader(wrap(strings.NewReader(c.in))))
+			if err != nil || string(got) != c.trim {
+				t.Errorf("%q trimmed: got %q, %v want %q", c.in, got, err, c.trim)
+			}
+		}
+
+		//every split of the input across two reads
+		for i := 0; i <= len(c.in); i++ {
+			r := NewScriptedReader([]byte(c.in), []int{i, len(c.in)})
+			if got, _ := io.ReadAll(NewWhitespaceCollapseReader(r)); string(got) != c.collapse {
+				t.Errorf("%q split at %d: got %q", c.in, i, got)
+			}
+		}
+	}
+}
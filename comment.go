@@ -0,0 +1,167 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+//ErrUnterminatedComment is returned by a CommentStripReader
+//for a stream that ends within a block comment,
+//unless AllowUnterminated is set.
+var ErrUnterminatedComment = errors.New("simple: unterminated block comment")
+
+//CommentStripReader is a Reader that removes comments.
+//
+//Its fields must be set before the first Read.
+type CommentStripReader struct {
+	*Reader
+
+	//Quote, if not 0, begins and ends strings within which
+	//comment delimiters are not recognized.
+	//Within a string, \ escapes the following byte.
+	Quote byte
+	//AllowUnterminated, if set, makes a block comment still open
+	//at the end of the stream end with it, rather than ErrUnterminatedComment.
+	AllowUnterminated bool
+}
+
+//NewCommentStripReader wraps r in a CommentStripReader that removes
+//line comments, from lineComment up to but not including the next \n,
+//and block comments, from blockStart through the next blockEnd.
+//Block comments do not nest.
+//Either kind is disabled if its delimiter is empty.
+//
+//Delimiters may span any number of reads of r.
+//
+//NewCommentStripReader panics if blockStart is given without blockEnd.
+func NewCommentStripReader(r io.Reader, lineComment, blockStart, blockEnd []byte) *CommentStripReader {
+	if len(blockStart) > 0 && len(blockEnd) == 0 {
+		panic("block comment must have an end")
+	}
+	c := &CommentStripReader{}
+	c.Reader = newTransformReader(r, &commentTransform{
+		cfg:   c,
+		line:  append([]byte(nil), lineComment...),
+		start: append([]byte(nil), blockStart...),
+		end:   append([]byte(nil), blockEnd...),
+	})
+	return c
+}
+
+type commentState int
+
+const (
+	inCode commentState = iota
+	inQuote
+	inEscape //after \ in a quote
+	inLine
+	inBlock
+)
+
+type commentTransform struct {
+	cfg              *CommentStripReader
+	line, start, end []byte
+	state            commentState
+	held             []byte //a possible delimiter, cut short by the end of a read
+}
+
+func (t *commentTransform) push(out, in []byte) ([]byte, error) {
+	buf := append(t.held, in...)
+	out, n := t.process(out, buf, false)
+	t.held = append(t.held[:0], buf[n:]...)
+	return out, nil
+}
+
+func (t *commentTransform) flush(out []byte) ([]byte, error) {
+	out, _ = t.process(out, t.held, true)
+	t.held = t.held[:0]
+	if t.state == inBlock && !t.cfg.AllowUnterminated {
+		return out, ErrUnterminatedComment
+	}
+	return out, nil
+}
+
+//match reports whether delim begins b, or might, once more is read.
+func match(b, delim []byte, eof bool) (found, maybe bool) {
+	if len(delim) == 0 {
+		return false, false
+	}
+	if bytes.HasPrefix(b, delim) {
+		return true, false
+	}
+	return false, !eof && len(b) < len(delim) && bytes.HasPrefix(delim, b)
+}
+
+//process appends the output for buf to out
+//and returns how much of buf was consumed.
+func (t *commentTransform) process(out, buf []byte, eof bool) ([]byte, int) {
+	quote := t.cfg.Quote
+	i := 0
+	for i < len(buf) {
+		b := buf[i]
+		switch t.state {
+		case inQuote:
+			out = append(out, b)
+			switch b {
+			case '\\':
+				t.state = inEscape
+			case quote:
+				t.state = inCode
+			}
+			i++
+			continue
+		case inEscape:
+			out = append(out, b)
+			t.state = inQuote
+			i++
+			continue
+		case inLine:
+			if b == '\n' {
+				t.state = inCode
+				continue
+			}
+			i++
+			continue
+		case inBlock:
+			found, maybe := match(buf[i:], t.end, eof)
+			switch {
+			case found:
+				t.state = inCode
+				i += len(t.end)
+			case maybe:
+				return out, i
+			default:
+				i++
+			}
+			continue
+		}
+
+		//in code, the longer delimiter first, in case one begins the other
+		line, block := t.line, t.start
+		lineState, blockState := inLine, inBlock
+		if len(line) < len(block) {
+			line, block = block, line
+			lineState, blockState = blockState, lineState
+		}
+		found1, maybe1 := match(buf[i:], line, eof)
+		found2, maybe2 := match(buf[i:], block, eof)
+		switch {
+		case maybe1 || maybe2 && !found1:
+			return out, i
+		case found1:
+			t.state = lineState
+			i += len(line)
+		case found2:
+			t.state = blockState
+			i += len(block)
+		default:
+			if quote != 0 && b == quote {
+				t.state = inQuote
+			}
+			out = append(out, b)
+			i++
+		}
+	}
+	return out, i
+}
@@ -0,0 +1,65 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCommentStripReader(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"a // line\nb", "a \nb"},
+		{"a /* block */b", "a b"},
+		{"/* a /* b */ c */", " c */"},
+		{"a /* // */ b // /* c\nd", "a  b \nd"},
+		{"a /* multi\nline */ b", "a  b"},
+		{"a / b * c /", "a / b * c /"},
+		{"x = \"// not /* a comment\" // but this is", "x = \"// not /* a comment\" "},
+		{`"a \" // b" // c`, `"a \" // b" `},
+		{"a /", "a /"},
+	}
+	for _, tt := range tests {
+		for i := 0; i <= len(tt.in); i++ {
+			c := NewCommentStripReader(NewScriptedReader([]byte(tt.in), []int{i, 1}), []byte("//"), []byte("/*"), []byte("*/"))
+			c.Quote = '"'
+			got, err := io.ReadAll(c)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("%q split at %d: got %q, %v want %q", tt.in, i, got, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestCommentStripReaderNoQuote(t *testing.T) {
+	c := NewCommentStripReader(strings.NewReader(`a "# b" # c`), []byte("#"), nil, nil)
+	if got, err := io.ReadAll(c); err != nil || string(got) != `a "` {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestCommentStripReaderPrefixDelims(t *testing.T) {
+	//the block comment delimiter begins with the line comment delimiter
+	const in = "a #| b |# c # d\ne"
+	for i := 0; i <= len(in); i++ {
+		c := NewCommentStripReader(NewScriptedReader([]byte(in), []int{i, 1}), []byte("#"), []byte("#|"), []byte("|#"))
+		if got, err := io.ReadAll(c); err != nil || string(got) != "a  c \ne" {
+			t.Errorf("split at %d: got %q, %v", i, got, err)
+		}
+	}
+}
+
+func TestCommentStripReaderUnterminated(t *testing.T) {
+	const in = "a /* b *"
+	c := NewCommentStripReader(strings.NewReader(in), nil, []byte("/*"), []byte("*/"))
+	if got, err := io.ReadAll(c); string(got) != "a " || err != ErrUnterminatedComment {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	c = NewCommentStripReader(strings.NewReader(in), nil, []byte("/*"), []byte("*/"))
+	c.AllowUnterminated = true
+	if got, err := io.ReadAll(c); string(got) != "a " || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,76 @@
+package simple
+
+import (
+	"io"
+)
+
+//Drain reads and discards the rest of the stream
+//and returns the number of bytes discarded.
+//
+//Reaching io.EOF is not an error.
+func (r *Reader) Drain() (int64, error) {
+	return io.Copy(io.Discard, r)
+}
+
+//DrainAndClose drains the stream, as by Drain,
+//then closes the wrapped io.Reader, if it is an io.Closer.
+//
+//It is suited to a defer where leftover data is expected and harmless,
+//such as an HTTP response body being drained so the connection can be reused.
+//The error from draining is returned in preference to that from closing.
+func (r *Reader) DrainAndClose() error {
+	_, err := r.Drain()
+	if c, ok := r.r.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+//MustConsume returns a function that panics
+//with a *NotAtEOFError if the stream has not been read to the end.
+//It is meant to be deferred, as in
+//
+//	defer r.MustConsume()()
+//
+//to catch code that is meant to read all of a stream and forgets to.
+//
+//The check is made when the returned function is called, by AssertEOF,
+//so if all the data has been read it is not necessary to have seen io.EOF.
+//Any other error from that read is ignored,
+//since it means the stream cannot be read further.
+//
+//When leftover data should be discarded rather than treated as a bug,
+//defer DrainAndClose instead.
+//To do both, defer MustConsumeAndClose.
+func (r *Reader) MustConsume() func() {
+	return func() {
+		if err := AssertEOF(r); err != nil {
+			if e, ok := err.(*NotAtEOFError); ok {
+				panic(e)
+			}
+		}
+	}
+}
+
+//MustConsumeAndClose is like MustConsume except that the returned function
+//then drains and closes the stream, as by DrainAndClose,
+//before any panic.
+//It is meant to be deferred, as in
+//
+//	defer r.MustConsumeAndClose()()
+//
+//so that under-consumption is still caught,
+//but the stream is released regardless,
+//such as an HTTP response body whose connection can then be reused.
+//Errors from draining or closing are ignored.
+func (r *Reader) MustConsumeAndClose() func() {
+	return func() {
+		err := AssertEOF(r)
+		r.DrainAndClose()
+		if e, ok := err.(*NotAtEOFError); ok {
+			panic(e)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func ExampleReader_MustConsume() {
+	parse := func(r *Reader) {
+		defer r.MustConsume()()
+		p := make([]byte, 5)
+		io.ReadFull(r, p)
+		fmt.Printf("%s\n", p)
+	}
+
+	parse(NewReader(strings.NewReader("hello")))
+
+	defer func() {
+		fmt.Println("panic:", recover())
+	}()
+	parse(NewReader(strings.NewReader("hello, world")))
+	// Output:
+	// hello
+	// hello
+	// panic: simple: reader not at EOF: 7 leftover bytes: ", world"
+}
+
+func TestMustConsume(t *testing.T) {
+	panicked := func(r *Reader) (v any) {
+		defer func() { v = recover() }()
+		r.MustConsume()()
+		return nil
+	}
+
+	r := NewReader(strings.NewReader("abc"))
+	io.ReadFull(r, make([]byte, 2))
+	v := panicked(r)
+	if err, ok := v.(error); !ok || !errors.Is(err, ErrNotAtEOF) {
+		t.Errorf("got %v want ErrNotAtEOF panic", v)
+	}
+
+	r = NewReader(strings.NewReader("abc"))
+	io.ReadAll(r)
+	if v := panicked(r); v != nil {
+		t.Errorf("panicked for a consumed reader: %v", v)
+	}
+
+	//a failed stream cannot be read further so it does not panic
+	r = NewReader(iotest.ErrReader(errTruncated))
+	if v := panicked(r); v != nil {
+		t.Errorf("panicked for a failed reader: %v", v)
+	}
+}
+
+type closeCounter struct {
+	io.Reader
+	closes int
+}
+
+func (c *closeCounter) Close() error {
+	c.closes++
+	return nil
+}
+
+func TestDrainAndClose(t *testing.T) {
+	src := &closeCounter{Reader: strings.NewReader("abcdef")}
+	r := NewReader(src)
+	io.ReadFull(r, make([]byte, 2))
+	if n, err := r.Drain(); n != 4 || err != nil {
+		t.Errorf("Drain: got %d, %v", n, err)
+	}
+
+	src = &closeCounter{Reader: &dataErr{"abc", errTruncated}}
+	r = NewReader(src)
+	if err := r.DrainAndClose(); err != errTruncated {
+		t.Errorf("got %v want %v", err, errTruncated)
+	}
+	if src.closes != 1 {
+		t.Errorf("closed %d times", src.closes)
+	}
+}
+
+func TestMustConsumeAndClose(t *testing.T) {
+	panicked := func(r *Reader) (v any) {
+		defer func() { v = recover() }()
+		r.MustConsumeAndClose()()
+		return nil
+	}
+
+	src := &closeCounter{Reader: strings.NewReader("abcdef")}
+	r := NewReader(src)
+	io.ReadFull(r, make([]byte, 2))
+	if v, ok := panicked(r).(error); !ok || !errors.Is(v, ErrNotAtEOF) {
+		t.Errorf("got %v want ErrNotAtEOF panic", v)
+	}
+	if src.closes != 1 {
+		t.Errorf("closed %d times", src.closes)
+	}
+	if n, err := r.Drain(); n != 0 || err != nil {
+		t.Errorf("not drained: got %d, %v", n, err)
+	}
+
+	src = &closeCounter{Reader: strings.NewReader("abc")}
+	r = NewReader(src)
+	io.ReadAll(r)
+	if v := panicked(r); v != nil {
+		t.Errorf("panicked for a consumed reader: %v", v)
+	}
+	if src.closes != 1 {
+		t.Errorf("closed %d times", src.closes)
+	}
+}
@@ -0,0 +1,101 @@
+package simple
+
+import "io"
+
+//NewContinuationReader returns a Reader that joins each line of r
+//that ends with the continuation byte to the line that follows it,
+//removing the continuation byte and the line ending.
+//
+//Lines end with \n or \r\n.
+//A continuation byte at the end of the stream, not followed by a line ending,
+//is delivered as is.
+func NewContinuationReader(r io.Reader, continuation byte) *Reader {
+	return newTransformReader(r, &continuationTransform{marker: continuation})
+}
+
+type continuationTransform struct {
+	marker byte
+	state  int //0, or 1 after a marker, or 2 after a marker and \r
+}
+
+func (c *continuationTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch c.state {
+		case 1:
+			switch b {
+			case '\n':
+				c.state = 0
+				continue
+			case '\r':
+				c.state = 2
+				continue
+			}
+			out = append(out, c.marker)
+		case 2:
+			c.state = 0
+			if b == '\n' {
+				continue
+			}
+			out = append(out, c.marker, '\r')
+		}
+
+		if b == c.marker {
+			c.state = 1
+		} else {
+			c.state = 0
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (c *continuationTransform) flush(out []byte) ([]byte, error) {
+	switch c.state {
+	case 1:
+		out = append(out, c.marker)
+	case 2:
+		out = append(out, c.marker, '\r')
+	}
+	c.state = 0
+	return out, nil
+}
+
+//NewUnfoldReader returns a Reader that unfolds RFC 822 style folded lines
+//read from r, by removing each line ending, \r\n or \n,
+//that is followed by a space or tab.
+//The whitespace beginning the continuation line is kept.
+func NewUnfoldReader(r io.Reader) *Reader {
+	return newTransformReader(r, &unfoldTransform{})
+}
+
+type unfoldTransform struct {
+	held []byte //a line ending, or a \r that may begin one
+}
+
+func (u *unfoldTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch {
+		case len(u.held) == 1 && u.held[0] == '\r' && b == '\n':
+			u.held = append(u.held, b)
+			continue
+		case len(u.held) > 0 && u.held[len(u.held)-1] == '\n' && (b == ' ' || b == '\t'):
+			u.held = u.held[:0]
+		case len(u.held) > 0:
+			out = append(out, u.held...)
+			u.held = u.held[:0]
+		}
+
+		if b == '\r' || b == '\n' {
+			u.held = append(u.held, b)
+		} else {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (u *unfoldTransform) flush(out []byte) ([]byte, error) {
+	out = append(out, u.held...)
+	u.held = u.held[:0]
+	return out, nil
+}
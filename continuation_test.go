@@ -0,0 +1,50 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestContinuationReader(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"a \\\nb \\\r\nc\nd\n", "a b c\nd\n"},
+		{"one\\\ntwo\\\nthree\\\n", "onetwothree"},
+		{"a\\\\\nb", "a\\b"},
+		{"a\\b\\\rc", "a\\b\\\rc"},
+		{"trailing\\", "trailing\\"},
+		{"trailing\\\r", "trailing\\\r"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewContinuationReader(wrap(strings.NewReader(c.in)), '\\'))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.want {
+				t.Errorf("%q: got %q want %q", c.in, got, c.want)
+			}
+		}
+	}
+}
+
+func TestUnfoldReader(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"Subject: a\r\n long\r\n\tsubject\r\nTo: b\r\n", "Subject: a long\tsubject\r\nTo: b\r\n"},
+		{"A: 1\n 2\nB: 3", "A: 1 2\nB: 3"},
+		{"A: 1\r \r\n\r\n", "A: 1\r \r\n\r\n"},
+		{"end\r\n", "end\r\n"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewUnfoldReader(wrap(strings.NewReader(c.in))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.want {
+				t.Errorf("%q: got %q want %q", c.in, got, c.want)
+			}
+		}
+	}
+}
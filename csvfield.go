@@ -0,0 +1,163 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrMalformedCSV is returned, wrapped with more detail,
+//by CSVFieldReader.ReadRecord for a misplaced quote.
+var ErrMalformedCSV = errors.New("simple: malformed CSV")
+
+//CSVFieldReader is a Reader that can also read CSV records.
+type CSVFieldReader struct {
+	*Reader
+	br           *bufio.Reader
+	comma, quote byte
+	line         int
+}
+
+//NewCSVFieldReader wraps r in a CSVFieldReader
+//whose fields are separated by comma and may be quoted by quote.
+//
+//r is buffered, so more may be read from r than has been delivered.
+//
+//NewCSVFieldReader panics if comma and quote are the same,
+//or either is \r or \n.
+func NewCSVFieldReader(r io.Reader, comma, quote byte) *CSVFieldReader {
+	must(r)
+	switch {
+	case comma == quote:
+		panic("comma and quote must differ")
+	case comma == '\r' || comma == '\n' || quote == '\r' || quote == '\n':
+		panic("comma and quote cannot be line endings")
+	}
+	br := bufio.NewReader(r)
+	return &CSVFieldReader{
+		Reader: NewReader(br),
+		br:     br,
+		comma:  comma,
+		quote:  quote,
+	}
+}
+
+//ReadRecord reads one record, a line of fields, as in RFC 4180,
+//and returns its fields.
+//
+//Lines end with \n or \r\n, or the end of the stream, and blank lines
+//are skipped.
+//A field beginning with quote is quoted: it ends at the next lone quote
+//and may contain comma, line endings, and quote, doubled.
+//A quote anywhere else in a field, or anything but comma or the end of
+//the line after a quoted field, results in an error wrapping
+//ErrMalformedCSV, and the next call reads from the byte after it.
+//
+//Records may span any number of reads of the wrapped io.Reader.
+//ReadRecord returns io.EOF only once every record has been read.
+//If the stream ends within a quoted field,
+//ReadRecord returns io.ErrUnexpectedEOF.
+//
+//ReadRecord may be mixed with Read, which delivers the input as is.
+func (c *CSVFieldReader) ReadRecord() ([][]byte, error) {
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+
+	var record [][]byte
+	var field []byte
+	start := true //whether at the start of a field
+	c.line++
+	for {
+		b, err := c.br.ReadByte()
+		if err != nil {
+			field = bytes.TrimSuffix(field, []byte{'\r'})
+			if err != io.EOF || record == nil && len(field) == 0 {
+				return nil, c.surface(err)
+			}
+			return append(record, field), nil
+		}
+
+		switch {
+		case start && b == c.quote:
+			if field, err = c.readQuoted(); err != nil {
+				return nil, err
+			}
+			switch b, err := c.br.ReadByte(); {
+			case err == io.EOF:
+				return append(record, field), nil
+			case err != nil:
+				return nil, c.surface(err)
+			case b == c.comma:
+				record, field = append(record, field), nil
+				continue
+			case b == '\n':
+				return append(record, field), nil
+			case b == '\r':
+				next, err := c.br.ReadByte()
+				switch {
+				case err == io.EOF || err == nil && next == '\n':
+					return append(record, field), nil
+				case err != nil:
+					return nil, c.surface(err)
+				}
+				//only the \r is at fault
+				c.br.UnreadByte()
+				fallthrough
+			default:
+				return nil, fmt.Errorf("%w: line %d: %q after quoted field", ErrMalformedCSV, c.line, b)
+			}
+		case b == c.comma:
+			record, field = append(record, field), nil
+			start = true
+			continue
+		case b == '\n':
+			field = bytes.TrimSuffix(field, []byte{'\r'})
+			if record == nil && len(field) == 0 {
+				//a blank line
+				c.line++
+				continue
+			}
+			return append(record, field), nil
+		case b == c.quote:
+			return nil, fmt.Errorf("%w: line %d: quote in unquoted field", ErrMalformedCSV, c.line)
+		default:
+			field = append(field, b)
+		}
+		start = false
+	}
+}
+
+//readQuoted reads the rest of a quoted field, through its closing quote.
+func (c *CSVFieldReader) readQuoted() ([]byte, error) {
+	field := []byte{}
+	for {
+		b, err := c.br.ReadByte()
+		if err != nil {
+			return nil, c.surface(noEOF(err))
+		}
+		if b == '\n' {
+			c.line++
+		}
+		if b != c.quote {
+			field = append(field, b)
+			continue
+		}
+
+		next, err := c.br.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				return field, nil
+			}
+			return nil, c.surface(err)
+		}
+		if next == c.quote {
+			field = append(field, b)
+			continue
+		}
+		c.br.UnreadByte()
+		return field, nil
+	}
+}
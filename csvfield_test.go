@@ -0,0 +1,80 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func csvRecords(c *CSVFieldReader) ([]string, error) {
+	var out []string
+	for {
+		rec, err := c.ReadRecord()
+		if err != nil {
+			return out, err
+		}
+		out = append(out, fmt.Sprintf("%q", rec))
+	}
+}
+
+func TestCSVFieldReader(t *testing.T) {
+	const in = "name,quote,n\r\n" +
+		`"Smith, J.","said ""hi""",1` + "\r\n" +
+		"\n" +
+		`"multi` + "\nline\",,\"\"\n" +
+		"last,x,\"y\""
+	want := []string{
+		`["name" "quote" "n"]`,
+		`["Smith, J." "said \"hi\"" "1"]`,
+		`["multi\nline" "" ""]`,
+		`["last" "x" "y"]`,
+	}
+	for i := 0; i <= len(in); i++ {
+		c := NewCSVFieldReader(NewScriptedReader([]byte(in), []int{i, 1, 4}), ',', '"')
+		got, err := csvRecords(c)
+		if err != io.EOF || strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("split at %d: got %v, %v", i, got, err)
+		}
+	}
+}
+
+func TestCSVFieldReaderDelims(t *testing.T) {
+	c := NewCSVFieldReader(strings.NewReader("a;'b;c';''''\nd;\n"), ';', '\'')
+	got, err := csvRecords(c)
+	if err != io.EOF || strings.Join(got, " ") != `["a" "b;c" "'"] ["d" ""]` {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestCSVFieldReaderMalformed(t *testing.T) {
+	tests := map[string]error{
+		"a\"b,c\n":       ErrMalformedCSV,
+		"\"a\"b,c\n":     ErrMalformedCSV,
+		"\"a,b\nc":       io.ErrUnexpectedEOF,
+		"ok\n\"a\" ,b\n": ErrMalformedCSV,
+	}
+	for in, want := range tests {
+		_, err := csvRecords(NewCSVFieldReader(strings.NewReader(in), ',', '"'))
+		if !errors.Is(err, want) {
+			t.Errorf("%q: got %v want %v", in, err, want)
+		}
+	}
+
+	_, err := csvRecords(NewCSVFieldReader(strings.NewReader("ok\n\"a\"b"), ',', '"'))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestCSVFieldReaderMalformedCR(t *testing.T) {
+	c := NewCSVFieldReader(strings.NewReader("\"a\"\rb,c\n"), ',', '"')
+	if _, err := c.ReadRecord(); !errors.Is(err, ErrMalformedCSV) {
+		t.Fatalf("got %v want ErrMalformedCSV", err)
+	}
+	//the byte after the \r is not lost
+	if rec, err := c.ReadRecord(); fmt.Sprintf("%q", rec) != `["b" "c"]` || err != nil {
+		t.Errorf("got %q, %v", rec, err)
+	}
+}
@@ -0,0 +1,65 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//errXZ is returned by NewAutoDecompressReader for xz streams,
+//which the standard library cannot decompress.
+var errXZ = fmt.Errorf("simple: xz decompression: %w", errors.ErrUnsupported)
+
+//NewAutoDecompressReader returns a Reader of the decompressed contents
+//of r if r begins with the magic bytes of a gzip, zlib, or bzip2 stream,
+//or of r unchanged otherwise.
+//
+//gzip streams are read as by NewMultiGzipReader.
+//
+//xz streams are recognized, but cannot be decompressed without
+//going outside the standard library, so an error wrapping
+//errors.ErrUnsupported is returned rather than passing them through.
+//
+//The zlib header is only two bytes and is chosen from a small set,
+//so a few short uncompressed inputs, like those beginning "x^",
+//are mistaken for zlib and fail to decompress.
+//
+//r is buffered, so more may be read from r than the stream.
+func NewAutoDecompressReader(r io.Reader) (*Reader, error) {
+	must(r)
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(6)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b, 8}):
+		return newGzipReader(br, true)
+	case isZlib(magic):
+		zr, err := zlib.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return NewReader(zr), nil
+	case len(magic) >= 4 && string(magic[:3]) == "BZh" && '1' <= magic[3] && magic[3] <= '9':
+		return NewReader(bzip2.NewReader(br)), nil
+	case bytes.HasPrefix(magic, []byte{0xfd, '7', 'z', 'X', 'Z', 0}):
+		return nil, errXZ
+	}
+	return NewReader(br), nil
+}
+
+//isZlib reports whether magic begins with a zlib header
+//for deflate without a preset dictionary.
+func isZlib(magic []byte) bool {
+	if len(magic) < 2 {
+		return false
+	}
+	cmf, flg := magic[0], magic[1]
+	return cmf&0x0f == 8 && cmf>>4 <= 7 && flg&0x20 == 0 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
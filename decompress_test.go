@@ -0,0 +1,75 @@
+package simple
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//bzip2ed is "Hello, World!\n" compressed by bzip2 -9.
+const bzip2ed = "\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\x99\xac\x22\x56\x00\x00\x02\x57\x80\x00\x10\x60\x04\x00\x40\x00\x80\x06\x04\x90\x00\x20\x00\x22\x06\x81\x90\x80\x69\xa6\x89\x18\x6a\xce\xa4\x19\x6f\x8b\xb9\x22\x9c\x28\x48\x4c\xd6\x11\x2b\x00"
+
+func TestAutoDecompressReader(t *testing.T) {
+	const want = "Hello, World!\n"
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(want))
+	gw.Close()
+
+	var zl bytes.Buffer
+	zw := zlib.NewWriter(&zl)
+	zw.Write([]byte(want))
+	zw.Close()
+
+	inputs := map[string]string{
+		"gzip":  gz.String(),
+		"zlib":  zl.String(),
+		"bzip2": bzip2ed,
+		"plain": want,
+	}
+	for name, in := range inputs {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			r, err := NewAutoDecompressReader(wrap(strings.NewReader(in)))
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != want {
+				t.Errorf("%s: got %q, %v", name, got, err)
+			}
+		}
+	}
+
+	//concatenated gzip members are all read
+	r, err := NewAutoDecompressReader(strings.NewReader(gz.String() + gz.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || string(got) != want+want {
+		t.Errorf("concatenated gzip: got %q, %v", got, err)
+	}
+
+	//inputs shorter than any magic are passed through
+	for _, in := range []string{"", "a", "BZh"} {
+		r, err := NewAutoDecompressReader(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("%q: %v", in, err)
+		}
+		if got, err := io.ReadAll(r); err != nil || string(got) != in {
+			t.Errorf("%q: got %q, %v", in, got, err)
+		}
+	}
+}
+
+func TestAutoDecompressReaderXZ(t *testing.T) {
+	_, err := NewAutoDecompressReader(strings.NewReader("\xfd7zXZ\x00\x00\x04"))
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("got %v want errors.ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,59 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//NewDedupReader returns a Reader that reads r in blocks of blockSize
+//bytes and, for each block identical to the block before it in r,
+//delivers dup(block) in its place.
+//
+//dup may return nil to drop the block or a marker to note the repeat.
+//If dup is nil, every block is delivered unchanged.
+//dup must not retain block.
+//
+//The final block is only compared if it is a full blockSize bytes;
+//a shorter final block is always delivered as is.
+//
+//NewDedupReader panics if blockSize < 1.
+func NewDedupReader(r io.Reader, blockSize int, dup func(block []byte) []byte) *Reader {
+	if blockSize < 1 {
+		panic("block size must be positive")
+	}
+	return newTransformReader(r, &dedupTransform{
+		cur: make([]byte, 0, blockSize),
+		dup: dup,
+	})
+}
+
+type dedupTransform struct {
+	cur, prev []byte
+	dup       func([]byte) []byte
+}
+
+func (d *dedupTransform) push(out, in []byte) ([]byte, error) {
+	for len(in) > 0 {
+		k := min(cap(d.cur)-len(d.cur), len(in))
+		d.cur = append(d.cur, in[:k]...)
+		in = in[k:]
+		if len(d.cur) < cap(d.cur) {
+			break
+		}
+
+		if d.dup != nil && d.prev != nil && bytes.Equal(d.cur, d.prev) {
+			out = append(out, d.dup(d.cur)...)
+		} else {
+			out = append(out, d.cur...)
+		}
+		d.prev = append(d.prev[:0], d.cur...)
+		d.cur = d.cur[:0]
+	}
+	return out, nil
+}
+
+func (d *dedupTransform) flush(out []byte) ([]byte, error) {
+	out = append(out, d.cur...)
+	d.cur = d.cur[:0]
+	return out, nil
+}
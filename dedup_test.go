@@ -0,0 +1,40 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDedupReader(t *testing.T) {
+	const in = "aaaa" + "aaaa" + "bbbb" + "aaaa" + "aaaa" + "aaaa" + "aa"
+	cases := []struct {
+		name string
+		dup  func([]byte) []byte
+		want string
+	}{
+		{"passthrough", nil, in},
+		{"collapse", func([]byte) []byte { return nil }, "aaaa" + "bbbb" + "aaaa" + "aa"},
+		{"mark", func([]byte) []byte { return []byte("*") }, "aaaa*bbbbaaaa**aa"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewDedupReader(wrap(strings.NewReader(in)), 4, c.dup))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.want {
+				t.Errorf("%s: got %q want %q", c.name, got, c.want)
+			}
+		}
+	}
+}
+
+func TestDedupReaderDistinct(t *testing.T) {
+	const in = "abcdefghijkl"
+	got, err := io.ReadAll(NewDedupReader(strings.NewReader(in), 3, func([]byte) []byte { return nil }))
+	if err != nil || string(got) != in {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,42 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//NewDefaultReader returns a Reader of r,
+//unless r ends without delivering any data,
+//in which case it is a Reader of def instead.
+//
+//Data read from r is delivered as it is read, so nothing is lost
+//deciding whether r is empty.
+//An error other than io.EOF before any data is returned as is,
+//without falling back to def.
+func NewDefaultReader(r io.Reader, def []byte) *Reader {
+	must(r)
+	return NewReader(&defaultReader{r: r, def: append([]byte(nil), def...)})
+}
+
+type defaultReader struct {
+	r       io.Reader
+	def     []byte
+	decided bool //whether r has delivered data or been replaced by def
+}
+
+func (d *defaultReader) Read(p []byte) (int, error) {
+	if d.decided {
+		return d.r.Read(p)
+	}
+
+	n, err := d.r.Read(p)
+	switch {
+	case n > 0:
+		d.decided = true
+	case err == io.EOF:
+		d.decided = true
+		d.r = bytes.NewReader(d.def)
+		return d.r.Read(p)
+	}
+	return n, err
+}
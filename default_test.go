@@ -0,0 +1,36 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDefaultReader(t *testing.T) {
+	const def = "key = default\n"
+	tests := map[string]struct {
+		r    io.Reader
+		want string
+	}{
+		"empty":       {strings.NewReader(""), def},
+		"content":     {strings.NewReader("key = value\n"), "key = value\n"},
+		"one byte":    {iotest.OneByteReader(strings.NewReader("x")), "x"},
+		"with EOF":    {iotest.DataErrReader(strings.NewReader("x")), "x"},
+		"stall first": {NewScriptedReader([]byte("ab"), []int{0, 1}), "ab"},
+	}
+	for name, tt := range tests {
+		got, err := io.ReadAll(NewDefaultReader(tt.r, []byte(def)))
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: got %q, %v want %q", name, got, err, tt.want)
+		}
+	}
+}
+
+func TestDefaultReaderError(t *testing.T) {
+	got, err := io.ReadAll(NewDefaultReader(iotest.ErrReader(errTruncated), []byte("default")))
+	if len(got) != 0 || !errors.Is(err, errTruncated) {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
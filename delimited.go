@@ -0,0 +1,71 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+//DelimitedReader is a Reader that splits its input on a rune.
+type DelimitedReader struct {
+	*Reader
+	br    *bufio.Reader
+	delim []byte
+}
+
+//NewDelimitedReader wraps r in a DelimitedReader splitting on the UTF-8
+//encoding of delim.
+//
+//r is buffered, so more may be read from r than has been delivered.
+//
+//NewDelimitedReader panics if delim is not a valid rune.
+func NewDelimitedReader(r io.Reader, delim rune) *DelimitedReader {
+	must(r)
+	if !utf8.ValidRune(delim) {
+		panic("invalid delimiter rune")
+	}
+	br := bufio.NewReader(r)
+	return &DelimitedReader{
+		Reader: NewReader(br),
+		br:     br,
+		delim:  utf8.AppendRune(nil, delim),
+	}
+}
+
+//ReadSegment returns the bytes up to the next delimiter,
+//without the delimiter, and consumes the delimiter.
+//
+//Segments and delimiters may span any number of reads of the wrapped
+//io.Reader.
+//Unlike Read, the last segment, which has no delimiter after it,
+//is returned together with io.EOF, as by bufio.Reader.ReadBytes.
+//It is empty if the stream ends with a delimiter.
+//Other errors are returned with whatever was read of the segment.
+//
+//ReadSegment may be mixed with Read:
+//a segment partly delivered by Read has the rest of it returned
+//by ReadSegment.
+func (d *DelimitedReader) ReadSegment() ([]byte, error) {
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+
+	//the last byte of a multi-byte encoding only ends the encoding,
+	//so each candidate delimiter ends in it
+	last := d.delim[len(d.delim)-1]
+	var seg []byte
+	for {
+		b, err := d.br.ReadSlice(last)
+		seg = append(seg, b...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return seg, d.surface(err)
+		}
+		if bytes.HasSuffix(seg, d.delim) {
+			return seg[:len(seg)-len(d.delim)], nil
+		}
+	}
+}
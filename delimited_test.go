@@ -0,0 +1,76 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func segments(t *testing.T, d *DelimitedReader) []string {
+	t.Helper()
+	var segs []string
+	for {
+		seg, err := d.ReadSegment()
+		segs = append(segs, string(seg))
+		if err == io.EOF {
+			return segs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDelimitedReader(t *testing.T) {
+	//the delimiter is 2 bytes, and ö ends in the same byte as ¶
+	const in = "one¶zwei ö¶¶drei"
+	want := []string{"one", "zwei ö", "", "drei"}
+	for i := 0; i <= len(in); i++ {
+		d := NewDelimitedReader(NewScriptedReader([]byte(in), []int{i, 1, 2}), '¶')
+		if got := segments(t, d); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("split at %d: got %q", i, got)
+		}
+	}
+}
+
+func TestDelimitedReaderTrailing(t *testing.T) {
+	d := NewDelimitedReader(strings.NewReader("a→b→"), '→')
+	if got := segments(t, d); strings.Join(got, "|") != "a|b|" {
+		t.Errorf("got %q", got)
+	}
+	if seg, err := d.ReadSegment(); len(seg) != 0 || err != io.EOF {
+		t.Errorf("got %q, %v", seg, err)
+	}
+}
+
+func TestDelimitedReaderLong(t *testing.T) {
+	//segments longer than the buffer
+	long := strings.Repeat("x", 10000)
+	d := NewDelimitedReader(iotest.HalfReader(strings.NewReader(long+"∎"+long)), '∎')
+	if got := segments(t, d); len(got) != 2 || got[0] != long || got[1] != long {
+		t.Errorf("got %d segments", len(got))
+	}
+}
+
+func TestDelimitedReaderMixed(t *testing.T) {
+	d := NewDelimitedReader(strings.NewReader("abc∎def"), '∎')
+	if _, err := io.ReadFull(d, make([]byte, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if seg, err := d.ReadSegment(); string(seg) != "c" || err != nil {
+		t.Errorf("got %q, %v", seg, err)
+	}
+}
+
+func TestDelimitedReaderError(t *testing.T) {
+	d := NewDelimitedReader(io.MultiReader(strings.NewReader("a,b"), iotest.ErrReader(errTruncated)), ',')
+	if seg, err := d.ReadSegment(); string(seg) != "a" || err != nil {
+		t.Errorf("got %q, %v", seg, err)
+	}
+	if seg, err := d.ReadSegment(); !bytes.Equal(seg, []byte("b")) || !errors.Is(err, errTruncated) {
+		t.Errorf("got %q, %v", seg, err)
+	}
+}
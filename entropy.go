@@ -0,0 +1,105 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+//ErrLowEntropy is returned, wrapped with more detail,
+//by a Reader from NewMinEntropyReader when its sample has too little entropy.
+var ErrLowEntropy = errors.New("simple: entropy below threshold")
+
+//EntropyReader is a Reader that estimates the Shannon entropy
+//of the start of its stream.
+type EntropyReader struct {
+	*Reader
+	e *entropyReader
+}
+
+//NewEntropyReader wraps r in an EntropyReader
+//that samples the first sampleSize bytes delivered.
+//
+//NewEntropyReader panics if sampleSize < 1.
+func NewEntropyReader(r io.Reader, sampleSize int) *EntropyReader {
+	return newEntropyReader(r, sampleSize, -1)
+}
+
+//NewMinEntropyReader is like NewEntropyReader
+//except that once the sample is full, if its entropy is below minBits,
+//the Reader returns an error wrapping ErrLowEntropy
+//after the data that completed the sample.
+//
+//A stream shorter than sampleSize is never rejected.
+func NewMinEntropyReader(r io.Reader, sampleSize int, minBits float64) *EntropyReader {
+	return newEntropyReader(r, sampleSize, minBits)
+}
+
+func newEntropyReader(r io.Reader, sampleSize int, minBits float64) *EntropyReader {
+	must(r)
+	if sampleSize < 1 {
+		panic("sample size must be positive")
+	}
+	e := &entropyReader{r: r, left: sampleSize, min: minBits}
+	return &EntropyReader{
+		Reader: NewReader(e),
+		e:      e,
+	}
+}
+
+//Entropy returns the Shannon entropy, in bits per byte from 0 to 8,
+//of the sample delivered so far.
+//It is 0 until any data has been delivered,
+//and stops changing once the sample is full.
+func (e *EntropyReader) Entropy() float64 {
+	return e.e.entropy()
+}
+
+type entropyReader struct {
+	r      io.Reader
+	counts [256]int
+	total  int
+	left   int     //bytes remaining in the sample
+	min    float64 //negative for no minimum
+	err    error
+}
+
+func (e *entropyReader) entropy() float64 {
+	if e.total == 0 {
+		return 0
+	}
+	h := 0.0
+	for _, c := range e.counts {
+		if c > 0 {
+			p := float64(c) / float64(e.total)
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
+
+func (e *entropyReader) Read(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	n, err := e.r.Read(p)
+	if e.left == 0 {
+		return n, err
+	}
+	k := min(n, e.left)
+	for _, b := range p[:k] {
+		e.counts[b]++
+	}
+	e.total += k
+	e.left -= k
+
+	if e.left == 0 && e.min >= 0 {
+		if h := e.entropy(); h < e.min {
+			e.err = fmt.Errorf("%w: %.2f bits per byte, want at least %.2f", ErrLowEntropy, h, e.min)
+			return n, e.err
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,73 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestEntropyReader(t *testing.T) {
+	random := make([]byte, 1<<16)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	r := NewEntropyReader(iotest.HalfReader(bytes.NewReader(random)), 1<<14)
+	if h := r.Entropy(); h != 0 {
+		t.Errorf("entropy %v before reading", h)
+	}
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	if h := r.Entropy(); h < 7.9 {
+		t.Errorf("random: entropy %v, want about 8", h)
+	}
+
+	r = NewEntropyReader(strings.NewReader(strings.Repeat("a", 1000)), 100)
+	io.ReadAll(r)
+	if h := r.Entropy(); h != 0 {
+		t.Errorf("repeated: entropy %v, want 0", h)
+	}
+
+	r = NewEntropyReader(strings.NewReader(strings.Repeat("ab", 1000)), 100)
+	io.ReadAll(r)
+	if h := r.Entropy(); h != 1 {
+		t.Errorf("alternating: entropy %v, want 1", h)
+	}
+}
+
+func TestEntropyReaderUpdates(t *testing.T) {
+	//the sample is "aaaa" then "aabb"; later bytes are not sampled
+	r := NewEntropyReader(strings.NewReader("aaaabbcdefgh"), 6)
+	p := make([]byte, 4)
+	Read(r, p)
+	if h := r.Entropy(); h != 0 {
+		t.Errorf("entropy %v, want 0", h)
+	}
+	Read(r, p)
+	Read(r, p)
+	if h := r.Entropy(); h < 0.918 || h > 0.919 {
+		t.Errorf("entropy %v, want 0.918", h)
+	}
+}
+
+func TestMinEntropyReader(t *testing.T) {
+	random := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(random)
+	if got, err := io.ReadAll(NewMinEntropyReader(bytes.NewReader(random), 500, 6)); err != nil || len(got) != 1000 {
+		t.Errorf("random: got %d bytes, %v", len(got), err)
+	}
+
+	in := strings.Repeat("a", 1000)
+	got, err := io.ReadAll(NewMinEntropyReader(iotest.OneByteReader(strings.NewReader(in)), 100, 6))
+	if !errors.Is(err, ErrLowEntropy) || len(got) != 100 {
+		t.Errorf("repeated: got %d bytes, %v", len(got), err)
+	}
+
+	//too short to judge
+	if _, err := io.ReadAll(NewMinEntropyReader(strings.NewReader("aaa"), 100, 6)); err != nil {
+		t.Errorf("short: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrNotAtEOF is matched, via errors.Is, by the *NotAtEOFError
+//returned from AssertEOF.
+var ErrNotAtEOF = errors.New("simple: reader not at EOF")
+
+//NotAtEOFError reports that a reader had data left when it should not.
+type NotAtEOFError struct {
+	//Leftover is the data returned by the read that found the reader
+	//was not at EOF.
+	//It is not necessarily all the data remaining.
+	Leftover []byte
+}
+
+func (e *NotAtEOFError) Error() string {
+	return fmt.Sprintf("%s: %d leftover bytes: %q", ErrNotAtEOF, len(e.Leftover), e.Leftover)
+}
+
+//Unwrap returns ErrNotAtEOF.
+func (e *NotAtEOFError) Unwrap() error {
+	return ErrNotAtEOF
+}
+
+//maxEOFPeek is how much AssertEOF reads to see if r is at EOF.
+const maxEOFPeek = 64
+
+//maxNoProgress is how many consecutive (0, nil) reads are tolerated
+//before giving up with io.ErrNoProgress.
+const maxNoProgress = 100
+
+//AssertEOF performs one more read of r and returns nil
+//if it cleanly returns io.EOF.
+//
+//If the read returns data, AssertEOF returns a *NotAtEOFError.
+//Any other error from the read is returned as is.
+//
+//If r is not a *Reader it is wrapped in one,
+//so that an error returned with data is not reported as EOF.
+func AssertEOF(r io.Reader) error {
+	sr, ok := r.(*Reader)
+	if !ok {
+		sr = NewReader(r)
+	}
+
+	p := make([]byte, maxEOFPeek)
+	for i := 0; i < maxNoProgress; i++ {
+		n, err := sr.Read(p)
+		switch {
+		case n > 0:
+			return &NotAtEOFError{Leftover: p[:n]}
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+	return io.ErrNoProgress
+}
@@ -0,0 +1,45 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAssertEOF(t *testing.T) {
+	r := strings.NewReader("key=value;garbage")
+	if _, err := io.ReadFull(r, make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	err := AssertEOF(r)
+	if !errors.Is(err, ErrNotAtEOF) {
+		t.Fatalf("got %v want ErrNotAtEOF", err)
+	}
+	var ne *NotAtEOFError
+	if !errors.As(err, &ne) || string(ne.Leftover) != "garbage" {
+		t.Errorf("got %#v want leftover %q", err, "garbage")
+	}
+
+	if err := AssertEOF(r); err != nil {
+		t.Errorf("exhausted reader: got %v", err)
+	}
+}
+
+func TestAssertEOFDataErr(t *testing.T) {
+	//the last read returns data with io.EOF; wrapping surfaces the data
+	b := NewBasic("!")
+	if err := AssertEOF(&b); !errors.Is(err, ErrNotAtEOF) {
+		t.Errorf("got %v want ErrNotAtEOF", err)
+	}
+
+	r := NewReader(iotest.DataErrReader(strings.NewReader("abc")))
+	if _, err := r.Read(make([]byte, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := AssertEOF(r); err != nil {
+		t.Errorf("stored EOF: got %v", err)
+	}
+}
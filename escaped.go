@@ -0,0 +1,103 @@
+package simple
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+//ErrTrailingEscape is returned by EscapedDelimReader.ReadField
+//for a stream that ends with an escape byte.
+var ErrTrailingEscape = errors.New("simple: escape at end of stream")
+
+//EscapedDelimReader is a Reader that splits its input on a delimiter byte
+//that may be escaped.
+type EscapedDelimReader struct {
+	*Reader
+	br            *bufio.Reader
+	delim, escape byte
+	lenient       bool
+}
+
+//NewEscapedDelimReader wraps r in an EscapedDelimReader splitting on delim,
+//except where it follows escape.
+//
+//r is buffered, so more may be read from r than has been delivered.
+//
+//NewEscapedDelimReader panics if delim and escape are the same.
+func NewEscapedDelimReader(r io.Reader, delim, escape byte) *EscapedDelimReader {
+	return newEscapedDelim(r, delim, escape, false)
+}
+
+//NewLenientEscapedDelimReader is like NewEscapedDelimReader
+//except that an escape at the end of the stream is kept,
+//rather than returning ErrTrailingEscape.
+func NewLenientEscapedDelimReader(r io.Reader, delim, escape byte) *EscapedDelimReader {
+	return newEscapedDelim(r, delim, escape, true)
+}
+
+func newEscapedDelim(r io.Reader, delim, escape byte, lenient bool) *EscapedDelimReader {
+	must(r)
+	if delim == escape {
+		panic("delimiter and escape must differ")
+	}
+	br := bufio.NewReader(r)
+	return &EscapedDelimReader{
+		Reader:  NewReader(br),
+		br:      br,
+		delim:   delim,
+		escape:  escape,
+		lenient: lenient,
+	}
+}
+
+//ReadField returns the bytes up to the next unescaped delimiter,
+//without the delimiter, and consumes the delimiter.
+//
+//In the field, an escaped delimiter or escape is replaced by itself,
+//without the escape.
+//An escape before any other byte is kept, as is the byte.
+//
+//Fields may span any number of reads of the wrapped io.Reader.
+//Unlike Read, the last field, which has no delimiter after it,
+//is returned together with io.EOF, as by DelimitedReader.ReadSegment,
+//or ErrTrailingEscape, if it ends with an escape,
+//unless the EscapedDelimReader is lenient.
+//Other errors are returned with whatever was read of the field.
+//
+//ReadField may be mixed with Read, which delivers the input as is.
+func (e *EscapedDelimReader) ReadField() ([]byte, error) {
+	if err := e.Err(); err != nil {
+		return nil, err
+	}
+
+	var field []byte
+	escaped := false
+	for {
+		b, err := e.br.ReadByte()
+		if err != nil {
+			if escaped {
+				if !e.lenient && err == io.EOF {
+					return field, ErrTrailingEscape
+				}
+				field = append(field, e.escape)
+			}
+			return field, e.surface(err)
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+			if b != e.delim && b != e.escape {
+				field = append(field, e.escape)
+			}
+			field = append(field, b)
+		case b == e.escape:
+			escaped = true
+		case b == e.delim:
+			return field, nil
+		default:
+			field = append(field, b)
+		}
+	}
+}
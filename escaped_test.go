@@ -0,0 +1,55 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func fields(e *EscapedDelimReader) ([]string, error) {
+	var out []string
+	for {
+		f, err := e.ReadField()
+		out = append(out, string(f))
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return out, err
+		}
+	}
+}
+
+func TestEscapedDelimReader(t *testing.T) {
+	const in = `a,b\,c,d\\,e\x,\,`
+	want := []string{"a", "b,c", `d\`, `e\x`, ","}
+	for i := 0; i <= len(in); i++ {
+		e := NewEscapedDelimReader(NewScriptedReader([]byte(in), []int{i, 1}), ',', '\\')
+		got, err := fields(e)
+		if err != nil || strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("split at %d: got %q, %v", i, got, err)
+		}
+	}
+}
+
+func TestEscapedDelimReaderTrailingEscape(t *testing.T) {
+	const in = `a,b\`
+	got, err := fields(NewEscapedDelimReader(strings.NewReader(in), ',', '\\'))
+	if !errors.Is(err, ErrTrailingEscape) || strings.Join(got, "|") != "a|b" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	got, err = fields(NewLenientEscapedDelimReader(strings.NewReader(in), ',', '\\'))
+	if err != nil || strings.Join(got, "|") != `a|b\` {
+		t.Errorf("lenient: got %q, %v", got, err)
+	}
+}
+
+func TestEscapedDelimReaderError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(`a\`), &dataErr{"", errTruncated})
+	e := NewEscapedDelimReader(r, ',', '\\')
+	if f, err := e.ReadField(); string(f) != `a\` || !errors.Is(err, errTruncated) {
+		t.Errorf("got %q, %v", f, err)
+	}
+}
@@ -0,0 +1,60 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrUnexpectedBytes is returned, wrapped with more detail, by ExpectBytes.
+var ErrUnexpectedBytes = errors.New("simple: unexpected bytes")
+
+//expectContext is how many bytes either side of a difference
+//ExpectBytes quotes.
+const expectContext = 16
+
+//ExpectBytes reads all of r, through a Reader,
+//and returns nil if it read exactly want.
+//
+//Otherwise, it returns an error wrapping ErrUnexpectedBytes that gives
+//the offset of the first difference and quotes the bytes around it
+//from both, or the error that stopped reading r.
+//
+//ExpectBytes is an aid for testing readers.
+func ExpectBytes(r io.Reader, want []byte) error {
+	got, err := io.ReadAll(NewReader(r))
+	if err != nil {
+		return fmt.Errorf("simple: after %d bytes: %w", len(got), err)
+	}
+
+	i := 0
+	for i < len(got) && i < len(want) && got[i] == want[i] {
+		i++
+	}
+	if i == len(got) && i == len(want) {
+		return nil
+	}
+
+	what := "differ"
+	switch i {
+	case len(got):
+		what = "are short"
+	case len(want):
+		what = "are long"
+	}
+	return fmt.Errorf("%w: %d bytes %s at offset %d: got %q want %q",
+		ErrUnexpectedBytes, len(got), what, i, window(got, i), window(want, i))
+}
+
+//window returns the bytes of b around i, marking any elision with "...".
+func window(b []byte, i int) string {
+	start, end := max(i-expectContext, 0), min(i+expectContext, len(b))
+	s := string(b[start:end])
+	if start > 0 {
+		s = "..." + s
+	}
+	if end < len(b) {
+		s += "..."
+	}
+	return s
+}
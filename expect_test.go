@@ -0,0 +1,42 @@
+package simple
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestExpectBytes(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 10)
+	tests := []struct {
+		got, want string
+		msg       string //empty if they match
+	}{
+		{"", "", ""},
+		{long, long, ""},
+		{"Hello, World!", "Hello, world!", `13 bytes differ at offset 7: got "Hello, World!" want "Hello, world!"`},
+		{long[:50] + "X" + long[51:], long, `100 bytes differ at offset 50: got "...efghijabcdefghijXbcdefghijabcdef..." want "...efghijabcdefghijabcdefghijabcdef..."`},
+		{"abc", "abcd", `3 bytes are short at offset 3: got "abc" want "abcd"`},
+		{"abcd", "abc", `4 bytes are long at offset 3: got "abcd" want "abc"`},
+	}
+	for _, tt := range tests {
+		err := ExpectBytes(iotest.DataErrReader(iotest.HalfReader(strings.NewReader(tt.got))), []byte(tt.want))
+		if tt.msg == "" {
+			if err != nil {
+				t.Errorf("%q: %v", tt.got, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrUnexpectedBytes) || err.Error() != ErrUnexpectedBytes.Error()+": "+tt.msg {
+			t.Errorf("%q: got %v want %s", tt.got, err, tt.msg)
+		}
+	}
+}
+
+func TestExpectBytesError(t *testing.T) {
+	err := ExpectBytes(&dataErr{"abc", errTruncated}, []byte("abc"))
+	if !errors.Is(err, errTruncated) || errors.Is(err, ErrUnexpectedBytes) {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package simple
+
+import (
+	"bufio"
+	"io"
+)
+
+//BufferedReader is a Reader with a buffer that can be parsed in place.
+type BufferedReader struct {
+	*Reader
+	b *bufferedReader
+}
+
+//NewBufferedReader wraps r in a BufferedReader with a buffer of size bytes.
+//
+//NewBufferedReader panics if size is not positive.
+func NewBufferedReader(r io.Reader, size int) *BufferedReader {
+	must(r)
+	if size < 1 {
+		panic("buffer size must be positive")
+	}
+	b := &bufferedReader{
+		r:   r,
+		buf: make([]byte, size),
+	}
+	return &BufferedReader{
+		Reader: NewReader(b),
+		b:      b,
+	}
+}
+
+//Fill returns the unconsumed contents of the buffer,
+//reading more from the wrapped io.Reader if it is empty,
+//or if they have already been returned by Fill and none have been consumed,
+//so that a parser can ask for more when it has only part of a token.
+//To make room, the contents are moved to the start of the buffer.
+//If it is already full, Fill returns the contents and bufio.ErrBufferFull.
+//
+//The returned slice is only valid until the next call to Fill, Consume,
+//or Read, and must not be modified.
+//
+//Unlike Read, Fill returns the contents together with any error from reading,
+//so that a parser can deal with a partial token at the end of the stream.
+func (b *BufferedReader) Fill() ([]byte, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+
+	bb := b.b
+	if bb.start < bb.end && !bb.seen {
+		bb.seen = true
+		return bb.buf[bb.start:bb.end], nil
+	}
+	bb.seen = true
+
+	if err := bb.err; err != nil {
+		bb.err = nil
+		return bb.buf[bb.start:bb.end], b.surface(err)
+	}
+
+	bb.end = copy(bb.buf, bb.buf[bb.start:bb.end])
+	bb.start = 0
+	if bb.end == len(bb.buf) {
+		return bb.buf, bufio.ErrBufferFull
+	}
+	for i := 0; i < maxNoProgress; i++ {
+		n, err := bb.r.Read(bb.buf[bb.end:])
+		bb.end += n
+		if n > 0 {
+			bb.err = err
+			return bb.buf[:bb.end], nil
+		}
+		if err != nil {
+			return bb.buf[:bb.end], b.surface(err)
+		}
+	}
+	return bb.buf[:bb.end], io.ErrNoProgress
+}
+
+//Consume discards the first n bytes of the contents of the buffer,
+//as returned by Fill, which are then counted as delivered, as by Read.
+//
+//Consume panics if n is negative or more than are buffered.
+func (b *BufferedReader) Consume(n int) {
+	bb := b.b
+	if n < 0 || n > bb.end-bb.start {
+		panic("cannot consume more than is buffered")
+	}
+	bb.start += n
+	b.off += int64(n)
+	if n > 0 {
+		bb.seen = false
+	}
+}
+
+type bufferedReader struct {
+	r          io.Reader
+	buf        []byte
+	start, end int   //the unconsumed contents of buf
+	seen       bool  //whether Fill has returned the contents
+	err        error //returned with the last data read by Fill
+}
+
+func (b *bufferedReader) Read(p []byte) (int, error) {
+	if b.start < b.end {
+		n := copy(p, b.buf[b.start:b.end])
+		b.start += n
+		b.seen = false
+		return n, nil
+	}
+	if err := b.err; err != nil {
+		b.err = nil
+		return 0, err
+	}
+	return b.r.Read(p)
+}
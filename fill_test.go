@@ -0,0 +1,138 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//fillWords splits b on spaces by parsing the buffer of b in place.
+func fillWords(b *BufferedReader, word func([]byte)) error {
+	for {
+		buf, err := b.Fill()
+		//parse every complete word
+		for {
+			i := bytes.IndexByte(buf, ' ')
+			if i < 0 {
+				break
+			}
+			word(buf[:i])
+			b.Consume(i + 1)
+			buf = buf[i+1:]
+		}
+		if err == io.EOF {
+			if len(buf) > 0 {
+				word(buf)
+				b.Consume(len(buf))
+			}
+			return nil
+		}
+		//including bufio.ErrBufferFull for a word too long to parse
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestBufferedReaderFill(t *testing.T) {
+	const in = "the quick brown fox jumps over the lazy dog"
+	for _, size := range []int{6, 8, 64} {
+		b := NewBufferedReader(iotest.HalfReader(strings.NewReader(in)), size)
+		var got []string
+		if err := fillWords(b, func(w []byte) { got = append(got, string(w)) }); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got, " ") != in {
+			t.Errorf("size %d: got %q", size, got)
+		}
+		if off, _ := b.Tell(); off != int64(len(in)) {
+			t.Errorf("size %d: consumed %d", size, off)
+		}
+	}
+}
+
+func TestBufferedReaderFull(t *testing.T) {
+	b := NewBufferedReader(strings.NewReader("abcdefgh"), 4)
+	if buf, err := b.Fill(); string(buf) != "abcd" || err != nil {
+		t.Fatalf("got %q, %v", buf, err)
+	}
+	if buf, err := b.Fill(); string(buf) != "abcd" || err != bufio.ErrBufferFull {
+		t.Errorf("got %q, %v", buf, err)
+	}
+
+	//a Fill after Consume returns the rest without reading
+	b.Consume(3)
+	if buf, err := b.Fill(); string(buf) != "d" || err != nil {
+		t.Errorf("got %q, %v", buf, err)
+	}
+	if buf, err := b.Fill(); string(buf) != "defg" || err != nil {
+		t.Errorf("got %q, %v", buf, err)
+	}
+}
+
+func TestBufferedReaderMixed(t *testing.T) {
+	b := NewBufferedReader(&dataErr{"abcdef", errTruncated}, 4)
+	if _, err := b.Fill(); err != nil {
+		t.Fatal(err)
+	}
+	b.Consume(1)
+	if p, err := Read(b, make([]byte, 2)); string(p) != "bc" || err != nil {
+		t.Errorf("got %q, %v", p, err)
+	}
+	if buf, err := b.Fill(); string(buf) != "d" || err != nil {
+		t.Errorf("got %q, %v", buf, err)
+	}
+	if buf, err := b.Fill(); string(buf) != "def" || err != nil {
+		t.Errorf("got %q, %v", buf, err)
+	}
+	if buf, err := b.Fill(); string(buf) != "def" || !errors.Is(err, errTruncated) {
+		t.Errorf("got %q, %v", buf, err)
+	}
+	if got, err := io.ReadAll(b); string(got) != "def" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestBufferedReaderConsumePanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("did not panic")
+		}
+	}()
+	NewBufferedReader(strings.NewReader("abc"), 4).Consume(1)
+}
+
+var benchWords = strings.Repeat("lorem ipsum dolor sit amet ", 1000)
+
+func BenchmarkBufferedReaderFill(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchWords)))
+	for i := 0; i < b.N; i++ {
+		n := 0
+		br := NewBufferedReader(strings.NewReader(benchWords), 4096)
+		if err := fillWords(br, func(w []byte) { n += len(w) }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+//BenchmarkBufferedReaderPerRead reads a copy of each word, for comparison.
+func BenchmarkBufferedReaderPerRead(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchWords)))
+	for i := 0; i < b.N; i++ {
+		n := 0
+		br := bufio.NewReaderSize(NewReader(strings.NewReader(benchWords)), 4096)
+		for {
+			w, err := br.ReadBytes(' ')
+			n += len(w)
+			if err != nil {
+				break
+			}
+		}
+	}
+}
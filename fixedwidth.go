@@ -0,0 +1,112 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	//ErrShortRecord is returned, wrapped with more detail,
+	//by FixedWidthReader.ReadRecord for a line shorter than the widths,
+	//unless Pad is set.
+	ErrShortRecord = errors.New("simple: record shorter than fields")
+	//ErrLongRecord is returned, wrapped with more detail,
+	//by FixedWidthReader.ReadRecord for a line longer than the widths.
+	ErrLongRecord = errors.New("simple: record longer than fields")
+)
+
+//FixedWidthReader reads lines of fixed-width fields.
+type FixedWidthReader struct {
+	//Pad, if not 0, is used to pad short lines to the full width
+	//instead of returning ErrShortRecord.
+	//It must be set before the first call to ReadRecord.
+	Pad byte
+
+	r      *bufio.Reader
+	widths []int
+	total  int
+	line   int
+}
+
+//NewFixedWidthReader returns a FixedWidthReader splitting the lines read
+//from r into fields of the given widths.
+//
+//Lines are terminated by \n or \r\n, or by the end of the stream.
+//
+//NewFixedWidthReader panics if any width is less than 1.
+func NewFixedWidthReader(r io.Reader, widths []int) *FixedWidthReader {
+	total := 0
+	for _, w := range widths {
+		if w < 1 {
+			panic("field widths must be positive")
+		}
+		total += w
+	}
+	return &FixedWidthReader{
+		r:      bufio.NewReaderSize(NewReader(r), total+2),
+		widths: append([]int(nil), widths...),
+		total:  total,
+	}
+}
+
+//ReadRecord reads one line and returns its fields.
+//
+//If the line does not contain exactly the total of the widths,
+//after any padding, ReadRecord returns an error wrapping ErrShortRecord
+//or ErrLongRecord and the next call reads the following line.
+//At the end of the stream, ReadRecord returns io.EOF.
+func (f *FixedWidthReader) ReadRecord() ([][]byte, error) {
+	line, err := f.r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		f.line++
+		if err := f.skipLine(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: line %d exceeds %d bytes", ErrLongRecord, f.line, f.total)
+	}
+	if len(line) == 0 {
+		return nil, err
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	f.line++
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	switch {
+	case len(line) > f.total:
+		return nil, fmt.Errorf("%w: line %d is %d bytes, want %d", ErrLongRecord, f.line, len(line), f.total)
+	case len(line) < f.total && f.Pad == 0:
+		return nil, fmt.Errorf("%w: line %d is %d bytes, want %d", ErrShortRecord, f.line, len(line), f.total)
+	}
+
+	rec := make([]byte, f.total)
+	n := copy(rec, line)
+	for i := n; i < len(rec); i++ {
+		rec[i] = f.Pad
+	}
+
+	fields := make([][]byte, len(f.widths))
+	for i, w := range f.widths {
+		fields[i], rec = rec[:w:w], rec[w:]
+	}
+	return fields, nil
+}
+
+//skipLine discards the remainder of an overlong line.
+func (f *FixedWidthReader) skipLine() error {
+	for {
+		_, err := f.r.ReadSlice('\n')
+		switch err {
+		case bufio.ErrBufferFull:
+			continue
+		case nil, io.EOF:
+			return nil
+		}
+		return err
+	}
+}
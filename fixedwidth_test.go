@@ -0,0 +1,65 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFixedWidthReader(t *testing.T) {
+	const in = "0001SMITH     NY\r\n0002JONES     CA\n0003DOE       TX"
+	want := "[[0001 SMITH      NY] [0002 JONES      CA] [0003 DOE        TX]]"
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewFixedWidthReader(wrap(strings.NewReader(in)), []int{4, 10, 2})
+		var recs [][]string
+		for {
+			rec, err := r.ReadRecord()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fs []string
+			for _, f := range rec {
+				fs = append(fs, string(f))
+			}
+			recs = append(recs, fs)
+		}
+		if got := fmt.Sprint(recs); got != want {
+			t.Errorf("got %s\nwant %s", got, want)
+		}
+	}
+}
+
+func TestFixedWidthReaderBadWidth(t *testing.T) {
+	const in = "0001SM\n" + "0002JONES     CAXXXXXXXXXXXXXXXXXXXXXXX\n" + "0003DOE       TXX\n" + "0004LEE       WA\n"
+	r := NewFixedWidthReader(strings.NewReader(in), []int{4, 10, 2})
+
+	wants := []error{ErrShortRecord, ErrLongRecord, ErrLongRecord, nil, io.EOF}
+	for i, want := range wants {
+		rec, err := r.ReadRecord()
+		if !errors.Is(err, want) {
+			t.Errorf("record %d: got %v want %v", i, err, want)
+		}
+		if want == nil && string(rec[0]) != "0004" {
+			t.Errorf("record %d: got %q", i, rec)
+		}
+	}
+}
+
+func TestFixedWidthReaderPad(t *testing.T) {
+	r := NewFixedWidthReader(strings.NewReader("0001SM\n"), []int{4, 10, 2})
+	r.Pad = ' '
+	rec, err := r.ReadRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%q", rec); got != `["0001" "SM        " "  "]` {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,151 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+//ErrFellBehind is returned by a Reader from ForkLimit that fell too far
+//behind the other Readers.
+var ErrFellBehind = errors.New("simple: forked reader fell too far behind")
+
+//Fork returns n Readers that each read the entire stream read from r,
+//independently and at their own pace.
+//
+//It is safe to use each of the Readers in a different goroutine.
+//
+//Data read from r is buffered until every Reader has read it,
+//so memory use grows with the gap between the fastest
+//and the slowest Reader, without limit,
+//and is reused as the slowest Reader catches up.
+//No Reader is ever blocked waiting for another, except while
+//one Reader reads more from r.
+//If a Reader is abandoned, everything read afterwards
+//by the others is retained.
+//Use ForkLimit to bound the buffer.
+//
+//Fork panics if n < 1.
+func Fork(r io.Reader, n int) []*Reader {
+	return newFork(r, n, 0)
+}
+
+//ForkLimit is like Fork except that no more than limit bytes are buffered.
+//
+//If reading more from r would require retaining more than limit bytes
+//for a Reader, that Reader is dropped and every subsequent Read on it
+//returns ErrFellBehind.
+//
+//ForkLimit panics if n < 1 or limit < 1.
+func ForkLimit(r io.Reader, n, limit int) []*Reader {
+	if limit < 1 {
+		panic("fork limit must be positive")
+	}
+	return newFork(r, n, limit)
+}
+
+func newFork(r io.Reader, n, limit int) []*Reader {
+	must(r)
+	if n < 1 {
+		panic("fork count must be positive")
+	}
+
+	f := &fork{
+		r:     r,
+		pos:   make([]int64, n),
+		limit: limit,
+	}
+	rs := make([]*Reader, n)
+	for i := range rs {
+		rs[i] = NewReader(&forkReader{f, i})
+	}
+	return rs
+}
+
+type fork struct {
+	mu    sync.Mutex
+	r     io.Reader
+	err   error  //error from the last read of r, returned after buf
+	buf   []byte //buf[:off] has been read by every reader
+	off   int
+	base  int64   //stream offset of buf[off]
+	pos   []int64 //stream offset of each reader, -1 if dropped
+	limit int
+}
+
+type forkReader struct {
+	f *fork
+	i int
+}
+
+func (fr *forkReader) Read(p []byte) (int, error) {
+	f := fr.f
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pos := f.pos[fr.i]
+	if pos < 0 {
+		return 0, ErrFellBehind
+	}
+
+	if pos == f.base+int64(len(f.buf)-f.off) {
+		if f.err != nil {
+			return 0, f.err
+		}
+		if n, err := f.fill(len(p)); n == 0 {
+			return 0, err
+		}
+	}
+
+	n := copy(p, f.buf[f.off+int(pos-f.base):])
+	f.pos[fr.i] += int64(n)
+	f.trim()
+	return n, nil
+}
+
+//fill reads up to n more bytes from r into buf.
+func (f *fork) fill(n int) (int, error) {
+	if f.limit > 0 && n > f.limit {
+		n = f.limit
+	}
+
+	m := len(f.buf)
+	if cap(f.buf)-m < n {
+		live := m - f.off
+		buf := make([]byte, live, 2*live+n)
+		copy(buf, f.buf[f.off:])
+		f.buf, f.off, m = buf, 0, live
+	}
+	n, f.err = f.r.Read(f.buf[m : m+n])
+	f.buf = f.buf[:m+n]
+
+	if f.limit > 0 {
+		end := f.base + int64(len(f.buf)-f.off)
+		for i, pos := range f.pos {
+			if pos >= 0 && end-pos > int64(f.limit) {
+				f.pos[i] = -1
+			}
+		}
+	}
+	return n, f.err
+}
+
+//trim discards the data every reader has read,
+//moving the rest to the start of buf once it is outweighed
+//by what has been discarded.
+func (f *fork) trim() {
+	low := int64(-1)
+	for _, pos := range f.pos {
+		if pos >= 0 && (low < 0 || pos < low) {
+			low = pos
+		}
+	}
+	if low > f.base {
+		f.off += int(low - f.base)
+		f.base = low
+	}
+	if live := len(f.buf) - f.off; f.off > 0 && f.off >= live {
+		copy(f.buf, f.buf[f.off:])
+		f.buf, f.off = f.buf[:live], 0
+	}
+}
@@ -0,0 +1,112 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestFork(t *testing.T) {
+	in := strings.Repeat("0123456789", 100)
+	rs := Fork(iotest.HalfReader(strings.NewReader(in)), 3)
+
+	var wg sync.WaitGroup
+	got := make([]string, len(rs))
+	for i, r := range rs {
+		wg.Add(1)
+		go func(i int, r *Reader) {
+			defer wg.Done()
+			var out bytes.Buffer
+			p := make([]byte, 1+7*i)
+			for {
+				p, err := Read(r, p)
+				if err != nil {
+					if err != io.EOF {
+						t.Error(err)
+					}
+					break
+				}
+				out.Write(p)
+				time.Sleep(time.Duration(i) * 10 * time.Microsecond)
+			}
+			got[i] = out.String()
+		}(i, r)
+	}
+	wg.Wait()
+
+	for i, s := range got {
+		if s != in {
+			t.Errorf("reader %d got %d bytes, want %d", i, len(s), len(in))
+		}
+	}
+}
+
+func TestForkLimit(t *testing.T) {
+	in := strings.Repeat("0123456789", 10)
+	rs := ForkLimit(strings.NewReader(in), 2, 8)
+
+	//the first reader reads everything while the second reads nothing
+	got, err := io.ReadAll(rs[0])
+	if err != nil || string(got) != in {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if _, err := rs[1].Read(make([]byte, 1)); err != ErrFellBehind {
+		t.Errorf("got %v want ErrFellBehind", err)
+	}
+}
+
+func TestForkLimitKeepsUp(t *testing.T) {
+	in := strings.Repeat("0123456789", 10)
+	rs := ForkLimit(strings.NewReader(in), 2, 8)
+
+	var outs [2]bytes.Buffer
+	p := make([]byte, 4)
+	for done := 0; done < 2; {
+		done = 0
+		for i, r := range rs {
+			p, err := Read(r, p)
+			if err == io.EOF {
+				done++
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			outs[i].Write(p)
+		}
+	}
+	for i := range outs {
+		if outs[i].String() != in {
+			t.Errorf("reader %d got %q", i, outs[i].String())
+		}
+	}
+}
+
+func TestForkReusesBuffer(t *testing.T) {
+	const gap = 100
+	rs := Fork(strings.NewReader(strings.Repeat("x", 10000*gap)), 2)
+	f := rs[0].r.(*forkReader).f
+	if _, err := io.ReadFull(rs[1], make([]byte, gap)); err != nil {
+		t.Fatal(err)
+	}
+	//the readers stay gap bytes apart through the stream
+	p := make([]byte, gap)
+	for {
+		if _, err := io.ReadFull(rs[1], p); err != nil {
+			break
+		}
+		if _, err := io.ReadFull(rs[0], p); err != nil {
+			t.Fatal(err)
+		}
+		if c := cap(f.buf); c > 16*gap {
+			t.Fatalf("buffer grew to %d bytes for a gap of %d", c, gap)
+		}
+	}
+	if got, err := io.ReadAll(rs[0]); len(got) != gap || err != nil {
+		t.Errorf("got %d bytes, %v", len(got), err)
+	}
+}
@@ -0,0 +1,97 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+//ErrFrameTooLarge is returned, wrapped with more detail, by ReadFrame
+//and Frames for a frame larger than allowed by WithMaxFrameSize.
+var ErrFrameTooLarge = errors.New("simple: frame too large")
+
+//WithMaxFrameSize limits the payloads returned by ReadFrame and Frames
+//to n bytes.
+//Without it, payloads are limited only by the width of the prefix.
+func WithMaxFrameSize(n int) Option {
+	return func(r *Reader) {
+		r.maxFrame = n
+	}
+}
+
+//ReadFrame reads one frame consisting of a prefixBytes wide length,
+//in the given byte order, followed by that many bytes of payload,
+//and returns the payload.
+//
+//ReadFrame returns io.EOF only if the stream ends before the frame begins.
+//If it ends within the frame, ReadFrame returns io.ErrUnexpectedEOF.
+//
+//ReadFrame panics if prefixBytes is not 1, 2, 4, or 8.
+func (r *Reader) ReadFrame(order binary.ByteOrder, prefixBytes int) ([]byte, error) {
+	var prefix [8]byte
+	n, err := io.ReadFull(r, prefix[:framePrefix(prefixBytes)])
+	if err != nil {
+		if err == io.ErrUnexpectedEOF && n == 0 {
+			err = io.EOF
+		}
+		return nil, err
+	}
+
+	var size uint64
+	switch prefixBytes {
+	case 1:
+		size = uint64(prefix[0])
+	case 2:
+		size = uint64(order.Uint16(prefix[:]))
+	case 4:
+		size = uint64(order.Uint32(prefix[:]))
+	case 8:
+		size = order.Uint64(prefix[:])
+	}
+	if r.maxFrame > 0 && size > uint64(r.maxFrame) {
+		return nil, fmt.Errorf("%w: %d bytes, max %d", ErrFrameTooLarge, size, r.maxFrame)
+	}
+
+	//grow the payload as it arrives rather than trusting size
+	payload, err := io.ReadAll(io.LimitReader(r, int64(min(size, 1<<63-1))))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(payload)) < size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return payload, nil
+}
+
+//framePrefix validates the width of a length prefix.
+func framePrefix(prefixBytes int) int {
+	switch prefixBytes {
+	case 1, 2, 4, 8:
+		return prefixBytes
+	}
+	panic("frame prefix must be 1, 2, 4, or 8 bytes")
+}
+
+//Frames returns an iterator over the payloads of successive frames,
+//as read by ReadFrame.
+//
+//Each payload is yielded with a nil error.
+//The last pair yielded has a nil payload and either io.EOF,
+//if the stream ended cleanly between frames, or the error that stopped it.
+func (r *Reader) Frames(order binary.ByteOrder, prefixBytes int) iter.Seq2[[]byte, error] {
+	framePrefix(prefixBytes)
+	return func(yield func([]byte, error) bool) {
+		for {
+			payload, err := r.ReadFrame(order, prefixBytes)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(payload, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReaderFrames(t *testing.T) {
+	const in = "\x00\x05hello" + "\x00\x00" + "\x00\x01," + "\x00\x05world"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		var got []string
+		var last error
+		for p, err := range NewReader(wrap(strings.NewReader(in))).Frames(binary.BigEndian, 2) {
+			if err != nil {
+				last = err
+				break
+			}
+			got = append(got, string(p))
+		}
+		if fmt.Sprintf("%q", got) != `["hello" "" "," "world"]` {
+			t.Errorf("got %q", got)
+		}
+		if last != io.EOF {
+			t.Errorf("got %v want io.EOF", last)
+		}
+	}
+}
+
+func TestReaderFramesTruncated(t *testing.T) {
+	cases := []string{
+		"\x02\x00\x00\x00hi" + "\x05\x00\x00\x00abc",
+		"\x02\x00\x00\x00hi" + "\x05\x00",
+	}
+	for _, in := range cases {
+		var got []string
+		var last error
+		for p, err := range NewReader(strings.NewReader(in)).Frames(binary.LittleEndian, 4) {
+			if err != nil {
+				last = err
+				continue
+			}
+			got = append(got, string(p))
+		}
+		if len(got) != 1 || got[0] != "hi" {
+			t.Errorf("%q: got %q", in, got)
+		}
+		if last != io.ErrUnexpectedEOF {
+			t.Errorf("%q: got %v want io.ErrUnexpectedEOF", in, last)
+		}
+	}
+}
+
+func TestReaderFramesTooLarge(t *testing.T) {
+	r := NewReader(strings.NewReader("\x03abc\x08abcdefgh"), WithMaxFrameSize(4))
+	var got []string
+	var last error
+	for p, err := range r.Frames(nil, 1) {
+		if err != nil {
+			last = err
+			continue
+		}
+		got = append(got, string(p))
+	}
+	if len(got) != 1 || !errors.Is(last, ErrFrameTooLarge) {
+		t.Errorf("got %q, %v", got, last)
+	}
+
+	//a huge length does not allocate up front
+	r = NewReader(strings.NewReader("\xff\xff\xff\xff\xff\xff\xff\xffabc"))
+	if _, err := r.ReadFrame(binary.BigEndian, 8); err != io.ErrUnexpectedEOF {
+		t.Errorf("got %v want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestReaderFramesStop(t *testing.T) {
+	r := NewReader(strings.NewReader("\x01a\x01b\x01c"))
+	for p, err := range r.Frames(nil, 1) {
+		if err != nil || string(p) != "a" {
+			t.Fatalf("got %q, %v", p, err)
+		}
+		break
+	}
+	if p, err := r.ReadFrame(nil, 1); err != nil || string(p) != "b" {
+		t.Errorf("got %q, %v", p, err)
+	}
+}
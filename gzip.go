@@ -0,0 +1,197 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+var (
+	//ErrGzipHeader is returned, possibly wrapped with more detail,
+	//for a malformed gzip header.
+	ErrGzipHeader = errors.New("simple: invalid gzip header")
+	//ErrGzipTrailer is returned, wrapped with more detail,
+	//when the CRC-32 or size in a gzip trailer does not match the data.
+	ErrGzipTrailer = errors.New("simple: gzip trailer mismatch")
+	//ErrGzipTrailingData is returned by a Reader from NewStrictGzipReader
+	//when data follows the gzip member.
+	ErrGzipTrailingData = errors.New("simple: data after gzip member")
+)
+
+//NewStrictGzipReader reads the gzip header from r and returns a Reader
+//of the decompressed data of the single gzip member read from r.
+//
+//The CRC-32 and size in the trailer are always checked against the data,
+//independently of compress/gzip, and a mismatch is returned
+//as an error wrapping ErrGzipTrailer in place of io.EOF.
+//A stream that ends before its trailer results in io.ErrUnexpectedEOF,
+//and any data after the trailer results in ErrGzipTrailingData,
+//so a damaged stream is never reported as a clean io.EOF.
+func NewStrictGzipReader(r io.Reader) (*Reader, error) {
+	return newGzipReader(r, false)
+}
+
+//NewMultiGzipReader is like NewStrictGzipReader
+//except that r may hold any number of concatenated gzip members,
+//and the Reader delivers their decompressed data as one stream.
+//
+//Each member's trailer is checked as it ends,
+//and errors name the 1-based number of the member at fault.
+//Data after a member that is not another gzip member
+//results in an error wrapping ErrGzipHeader.
+func NewMultiGzipReader(r io.Reader) (*Reader, error) {
+	return newGzipReader(r, true)
+}
+
+func newGzipReader(r io.Reader, multi bool) (*Reader, error) {
+	must(r)
+	g := &gzipReader{
+		r:     bufio.NewReader(r),
+		multi: multi,
+		crc:   crc32.NewIEEE(),
+	}
+	if err := g.header(); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return NewReader(g), nil
+}
+
+const (
+	gzipFHCRC    = 1 << 1
+	gzipFEXTRA   = 1 << 2
+	gzipFNAME    = 1 << 3
+	gzipFCOMMENT = 1 << 4
+)
+
+type gzipReader struct {
+	r       *bufio.Reader
+	multi   bool
+	member  int //1-based index of the current member
+	inflate io.ReadCloser
+	crc     hash.Hash32
+	size    uint32
+	err     error
+}
+
+//header reads a member header and starts decompressing the member.
+func (g *gzipReader) header() error {
+	g.member++
+	g.crc.Reset()
+	g.size = 0
+
+	//check what there is of the magic and method before anything else,
+	//so that a short run of garbage is not reported as truncation
+	var fixed [10]byte
+	n, err := io.ReadFull(g.r, fixed[:])
+	if magic := []byte{0x1f, 0x8b, 8}; !bytes.HasPrefix(magic, fixed[:min(n, len(magic))]) {
+		return fmt.Errorf("%w: member %d: bad magic or method", ErrGzipHeader, g.member)
+	}
+	if err != nil {
+		return err
+	}
+	flags := fixed[3]
+	hcrc := crc32.NewIEEE()
+	hcrc.Write(fixed[:])
+
+	if flags&gzipFEXTRA != 0 {
+		var xlen [2]byte
+		if _, err := io.ReadFull(g.r, xlen[:]); err != nil {
+			return noEOF(err)
+		}
+		hcrc.Write(xlen[:])
+		extra := make([]byte, binary.LittleEndian.Uint16(xlen[:]))
+		if _, err := io.ReadFull(g.r, extra); err != nil {
+			return noEOF(err)
+		}
+		hcrc.Write(extra)
+	}
+	for _, flag := range []byte{gzipFNAME, gzipFCOMMENT} {
+		if flags&flag == 0 {
+			continue
+		}
+		s, err := g.r.ReadBytes(0)
+		if err != nil {
+			return noEOF(err)
+		}
+		hcrc.Write(s)
+	}
+	if flags&gzipFHCRC != 0 {
+		var sum [2]byte
+		if _, err := io.ReadFull(g.r, sum[:]); err != nil {
+			return noEOF(err)
+		}
+		if binary.LittleEndian.Uint16(sum[:]) != uint16(hcrc.Sum32()) {
+			return fmt.Errorf("%w: member %d: header checksum mismatch", ErrGzipHeader, g.member)
+		}
+	}
+
+	if g.inflate == nil {
+		g.inflate = flate.NewReader(g.r)
+	} else {
+		g.inflate.(flate.Resetter).Reset(g.r, nil)
+	}
+	return nil
+}
+
+func (g *gzipReader) Read(p []byte) (int, error) {
+	for {
+		if g.err != nil {
+			return 0, g.err
+		}
+
+		n, err := g.inflate.Read(p)
+		g.crc.Write(p[:n])
+		g.size += uint32(n)
+		switch {
+		case err == io.EOF:
+			g.err = g.trailer()
+		case err != nil:
+			g.err = noEOF(err)
+		}
+		if n > 0 || g.err == nil && len(p) == 0 {
+			return n, nil
+		}
+	}
+}
+
+//trailer checks the trailer of a member once its data has been read.
+//It returns nil if there is another member to read.
+func (g *gzipReader) trailer() error {
+	var t [8]byte
+	if _, err := io.ReadFull(g.r, t[:]); err != nil {
+		return noEOF(err)
+	}
+	if want, got := binary.LittleEndian.Uint32(t[:4]), g.crc.Sum32(); got != want {
+		return fmt.Errorf("%w: member %d: CRC-32 is %08x, trailer says %08x", ErrGzipTrailer, g.member, got, want)
+	}
+	if want := binary.LittleEndian.Uint32(t[4:]); g.size != want {
+		return fmt.Errorf("%w: member %d: size mod 2^32 is %d, trailer says %d", ErrGzipTrailer, g.member, g.size, want)
+	}
+
+	if _, err := g.r.Peek(1); err == io.EOF {
+		return io.EOF
+	} else if err != nil {
+		return err
+	}
+	if !g.multi {
+		return ErrGzipTrailingData
+	}
+	return g.header()
+}
+
+//noEOF converts an io.EOF in the middle of a structure to io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
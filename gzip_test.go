@@ -0,0 +1,133 @@
+package simple
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Name = "test.txt"
+	zw.Comment = "a comment"
+	zw.Extra = []byte("xx")
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestStrictGzipReader(t *testing.T) {
+	in := strings.Repeat("Hello, World! ", 100)
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r, err := NewStrictGzipReader(wrap(bytes.NewReader(gzipped(t, in))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != in {
+			t.Errorf("got %d bytes, %v", len(got), err)
+		}
+	}
+}
+
+func TestStrictGzipReaderCorrupt(t *testing.T) {
+	z := gzipped(t, "Hello, World!")
+	corrupt := func(i int) []byte {
+		c := append([]byte(nil), z...)
+		c[len(c)+i] ^= 0xff
+		return c
+	}
+	cases := []struct {
+		name string
+		in   []byte
+		want error
+	}{
+		{"crc", corrupt(-8), ErrGzipTrailer},
+		{"size", corrupt(-1), ErrGzipTrailer},
+		{"truncated trailer", z[:len(z)-3], io.ErrUnexpectedEOF},
+		{"truncated data", z[:len(z)-12], io.ErrUnexpectedEOF},
+		{"trailing data", append(append([]byte(nil), z...), 0), ErrGzipTrailingData},
+	}
+	for _, c := range cases {
+		r, err := NewStrictGzipReader(bytes.NewReader(c.in))
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if _, err := io.ReadAll(r); !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v want %v", c.name, err, c.want)
+		}
+	}
+
+	if _, err := NewStrictGzipReader(strings.NewReader("not gzip at all")); !errors.Is(err, ErrGzipHeader) {
+		t.Errorf("got %v want ErrGzipHeader", err)
+	}
+	if _, err := NewStrictGzipReader(bytes.NewReader(z[:5])); err != io.ErrUnexpectedEOF {
+		t.Errorf("got %v want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestMultiGzipReader(t *testing.T) {
+	one, two := gzipped(t, "first member\n"), gzipped(t, "second member\n")
+	in := append(append([]byte(nil), one...), two...)
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r, err := NewMultiGzipReader(wrap(bytes.NewReader(in)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != "first member\nsecond member\n" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//a single member is fine too
+	r, err := NewMultiGzipReader(bytes.NewReader(one))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || string(got) != "first member\n" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestMultiGzipReaderCorrupt(t *testing.T) {
+	one, two := gzipped(t, "first member\n"), gzipped(t, "second member\n")
+	bad := append([]byte(nil), two...)
+	bad[len(bad)-5] ^= 0xff
+
+	cases := []struct {
+		name string
+		in   []byte
+		want error
+	}{
+		{"trailer", append(append([]byte(nil), one...), bad...), ErrGzipTrailer},
+		{"garbage", append(append([]byte(nil), one...), "garbage"...), ErrGzipHeader},
+		{"truncated", append(append([]byte(nil), one...), two[:20]...), io.ErrUnexpectedEOF},
+	}
+	for _, c := range cases {
+		r, err := NewMultiGzipReader(bytes.NewReader(c.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v want %v", c.name, err, c.want)
+		}
+		if c.want != io.ErrUnexpectedEOF && !strings.Contains(err.Error(), "member 2") {
+			t.Errorf("%s: %v does not name member 2", c.name, err)
+		}
+		if !strings.HasPrefix(string(got), "first member\n") {
+			t.Errorf("%s: got %q", c.name, got)
+		}
+	}
+}
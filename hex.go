@@ -0,0 +1,63 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrInvalidHex is returned, wrapped with more detail,
+//by a Reader from NewHexReader for input that is not hexadecimal.
+var ErrInvalidHex = errors.New("simple: invalid hex")
+
+//NewHexReader returns a Reader that decodes the hexadecimal text read from r,
+//two digits to a byte.
+//
+//Digits may be upper or lowercase and a byte's digits may be split
+//across reads of r.
+//Any other byte, including whitespace, results in an error wrapping
+//ErrInvalidHex that gives its offset, as does an odd number of digits.
+//The error is returned after the data that preceded it.
+func NewHexReader(r io.Reader) *Reader {
+	return newTransformReader(r, &hexTransform{})
+}
+
+//NewHexSkipSpaceReader is like NewHexReader
+//except that it skips spaces, tabs, and line endings,
+//as found in hex dumps, even between the two digits of a byte.
+func NewHexSkipSpaceReader(r io.Reader) *Reader {
+	return newTransformReader(r, &hexTransform{skipSpace: true})
+}
+
+type hexTransform struct {
+	skipSpace bool
+	odd       bool //whether hi holds the first digit of a byte
+	hi        byte
+	off       int64 //offset in the input of the start of in
+}
+
+func (h *hexTransform) push(out, in []byte) ([]byte, error) {
+	for i, b := range in {
+		switch {
+		case IsHexDigit(b):
+			if h.odd {
+				out = append(out, h.hi<<4|unhex(b))
+			} else {
+				h.hi = unhex(b)
+			}
+			h.odd = !h.odd
+		case h.skipSpace && (b == ' ' || b == '\t' || b == '\n' || b == '\r'):
+		default:
+			return out, fmt.Errorf("%w: %q at offset %d", ErrInvalidHex, b, h.off+int64(i))
+		}
+	}
+	h.off += int64(len(in))
+	return out, nil
+}
+
+func (h *hexTransform) flush(out []byte) ([]byte, error) {
+	if h.odd {
+		return out, fmt.Errorf("%w: odd number of digits", ErrInvalidHex)
+	}
+	return out, nil
+}
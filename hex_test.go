@@ -0,0 +1,57 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestHexReader(t *testing.T) {
+	const in = "48656c6C6f2c20576F726c6421"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewHexReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != "Hello, World!" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//an odd number of digits in each read
+	got, err := io.ReadAll(NewHexReader(NewScriptedReader([]byte(in), []int{3, 1, 5})))
+	if err != nil || string(got) != "Hello, World!" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestHexSkipSpaceReader(t *testing.T) {
+	const in = "4865 6c6c\n6f2c\t2057\r\n6 f 7 2 6c6421\n"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewHexSkipSpaceReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != "Hello, World!" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//but not by NewHexReader
+	got, err := io.ReadAll(NewHexReader(strings.NewReader(in)))
+	if !errors.Is(err, ErrInvalidHex) || !strings.Contains(err.Error(), "' ' at offset 4") || string(got) != "He" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestHexReaderInvalid(t *testing.T) {
+	cases := []struct{ in, before, msg string }{
+		{"4142zz", "AB", "'z' at offset 4"},
+		{"414g", "A", "'g' at offset 3"},
+		{"41424", "AB", "odd number of digits"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader} {
+			got, err := io.ReadAll(NewHexReader(wrap(strings.NewReader(c.in))))
+			if !errors.Is(err, ErrInvalidHex) || !strings.Contains(err.Error(), c.msg) || string(got) != c.before {
+				t.Errorf("%q: got %q, %v", c.in, got, err)
+			}
+		}
+	}
+}
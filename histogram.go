@@ -0,0 +1,71 @@
+package simple
+
+import (
+	"io"
+	"maps"
+	"math/bits"
+)
+
+//HistogramReader is a Reader that counts how many reads
+//of the wrapped io.Reader returned each number of bytes.
+type HistogramReader struct {
+	*Reader
+	h *histogramReader
+}
+
+//NewHistogramReader wraps r in a HistogramReader
+//that counts reads by their exact size.
+//
+//Every call to r.Read is counted, including those returning 0 bytes.
+//A read returning data and an error is counted once, by its data.
+//Returning the stored error later does not call r.Read,
+//so it is not counted again.
+func NewHistogramReader(r io.Reader) *HistogramReader {
+	return newHistogramReader(r, func(n int) int { return n })
+}
+
+//NewLog2HistogramReader is like NewHistogramReader
+//except that it counts reads by the largest power of two not exceeding
+//their size, or 0 for empty reads,
+//so that a wide range of sizes fits in a few buckets.
+//For example, reads of 4 through 7 bytes are all counted under 4.
+func NewLog2HistogramReader(r io.Reader) *HistogramReader {
+	return newHistogramReader(r, func(n int) int {
+		if n <= 0 {
+			return 0
+		}
+		return 1 << (bits.Len(uint(n)) - 1)
+	})
+}
+
+func newHistogramReader(r io.Reader, bucket func(int) int) *HistogramReader {
+	must(r)
+	h := &histogramReader{
+		r:      r,
+		bucket: bucket,
+		counts: map[int]int{},
+	}
+	return &HistogramReader{
+		Reader: NewReader(h),
+		h:      h,
+	}
+}
+
+//SizeHistogram returns the number of reads made so far of each size,
+//or size bucket.
+//The map is a copy that may be modified by the caller.
+func (h *HistogramReader) SizeHistogram() map[int]int {
+	return maps.Clone(h.h.counts)
+}
+
+type histogramReader struct {
+	r      io.Reader
+	bucket func(int) int
+	counts map[int]int
+}
+
+func (h *histogramReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	h.counts[h.bucket(n)]++
+	return n, err
+}
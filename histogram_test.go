@@ -0,0 +1,51 @@
+package simple
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHistogramReader(t *testing.T) {
+	in := strings.Repeat("x", 3+3+5+1+20)
+	r := NewHistogramReader(NewScriptedReader([]byte(in), []int{3, 3, 5, 1, 20}))
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	got := r.SizeHistogram()
+	if s := fmt.Sprint(got); s != "map[0:1 1:1 3:2 5:1 20:1]" {
+		t.Errorf("got %s", s)
+	}
+
+	//the copy is the caller's
+	got[3] = 100
+	if r.SizeHistogram()[3] != 2 {
+		t.Error("SizeHistogram returned its own map")
+	}
+}
+
+func TestHistogramReaderDeferredError(t *testing.T) {
+	r := NewHistogramReader(&dataErr{"abcdef", errTruncated})
+	p := make([]byte, 4)
+	for {
+		if _, err := r.Read(p); err != nil {
+			break
+		}
+	}
+	//the error came with the last 2 bytes and was returned without a read
+	if s := fmt.Sprint(r.SizeHistogram()); s != "map[2:1 4:1]" {
+		t.Errorf("got %s", s)
+	}
+}
+
+func TestLog2HistogramReader(t *testing.T) {
+	in := strings.Repeat("x", 1+4+7+8+300)
+	r := NewLog2HistogramReader(NewScriptedReader([]byte(in), []int{1, 4, 7, 8, 300}))
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	if s := fmt.Sprint(r.SizeHistogram()); s != "map[0:1 1:1 4:2 8:1 256:1]" {
+		t.Errorf("got %s", s)
+	}
+}
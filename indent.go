@@ -0,0 +1,73 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//NewIndentNormalizeReader returns a Reader that rewrites the indentation
+//of each line in r, the spaces and tabs that begin it,
+//with spaces if toSpaces, or otherwise with as many tabs as fit
+//followed by the spaces that remain.
+//
+//Tabs in the indentation of r reach the next multiple of fromTabWidth
+//columns, which is also the width of each tab written.
+//Nothing after the indentation is changed,
+//but lines containing only spaces and tabs are rewritten in full.
+//
+//Lines end with \n and may span any number of reads of r.
+//
+//NewIndentNormalizeReader panics if fromTabWidth is not positive.
+func NewIndentNormalizeReader(r io.Reader, fromTabWidth int, toSpaces bool) *Reader {
+	if fromTabWidth < 1 {
+		panic("tab width must be positive")
+	}
+	return newTransformReader(r, &indentTransform{
+		width:    fromTabWidth,
+		toSpaces: toSpaces,
+		indent:   true,
+	})
+}
+
+type indentTransform struct {
+	width    int
+	toSpaces bool
+	indent   bool //whether the indentation of the line is being read
+	col      int  //of the indentation so far
+}
+
+func (t *indentTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch {
+		case t.indent && b == ' ':
+			t.col++
+		case t.indent && b == '\t':
+			t.col += t.width - t.col%t.width
+		case b == '\n':
+			out = append(t.end(out), b)
+			t.indent = true
+		default:
+			out = append(t.end(out), b)
+		}
+	}
+	return out, nil
+}
+
+func (t *indentTransform) flush(out []byte) ([]byte, error) {
+	return t.end(out), nil
+}
+
+//end appends the indentation, if it has just ended.
+func (t *indentTransform) end(out []byte) []byte {
+	if !t.indent {
+		return out
+	}
+	spaces := t.col
+	if !t.toSpaces {
+		out = append(out, bytes.Repeat([]byte{'\t'}, t.col/t.width)...)
+		spaces = t.col % t.width
+	}
+	out = append(out, bytes.Repeat([]byte{' '}, spaces)...)
+	t.indent, t.col = false, 0
+	return out
+}
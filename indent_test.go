@@ -0,0 +1,51 @@
+package simple
+
+import (
+	"io"
+	"testing"
+)
+
+func TestIndentNormalizeReader(t *testing.T) {
+	const in = "func f() {\n" +
+		"\tif x {\n" +
+		"    \ty()\t// a\ttab\n" +
+		"  \t  z  \n" +
+		" \t\n" +
+		"\n" +
+		"   w \t"
+	tests := map[string]struct {
+		toSpaces bool
+		want     string
+	}{
+		"spaces": {true, "func f() {\n" +
+			"    if x {\n" +
+			"        y()\t// a\ttab\n" +
+			"      z  \n" +
+			"    \n" +
+			"\n" +
+			"   w \t"},
+		"tabs": {false, "func f() {\n" +
+			"\tif x {\n" +
+			"\t\ty()\t// a\ttab\n" +
+			"\t  z  \n" +
+			"\t\n" +
+			"\n" +
+			"   w \t"},
+	}
+	for name, tt := range tests {
+		for i := 0; i <= len(in); i++ {
+			r := NewIndentNormalizeReader(NewScriptedReader([]byte(in), []int{i, 1, 4}), 4, tt.toSpaces)
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("%s split at %d: got %q, %v", name, i, got, err)
+			}
+		}
+	}
+}
+
+func TestIndentNormalizeReaderTrailing(t *testing.T) {
+	r := NewIndentNormalizeReader(NewScriptedReader([]byte("x\n \t\t "), []int{1}), 2, false)
+	if got, err := io.ReadAll(r); string(got) != "x\n\t\t " || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,80 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrDecompressedTooLarge is returned, wrapped with more detail,
+//by a Reader from NewBoundedInflateReader
+//for a stream that decompresses to more than its limit.
+var ErrDecompressedTooLarge = errors.New("simple: decompressed stream too large")
+
+//NewBoundedInflateReader returns a Reader of the decompressed contents of r,
+//a gzip stream, read as by NewMultiGzipReader, or a zlib stream,
+//which returns an error wrapping ErrDecompressedTooLarge
+//if it would deliver more than maxDecompressed bytes.
+//
+//The stream is decompressed as it is read,
+//so the limit bounds the work done on untrusted input
+//rather than being checked afterward.
+//
+//If r begins with neither, NewBoundedInflateReader returns an error
+//wrapping ErrBadMagic.
+//
+//r is buffered, so more may be read from r than the stream.
+//
+//NewBoundedInflateReader panics if maxDecompressed is negative.
+func NewBoundedInflateReader(r io.Reader, maxDecompressed int64) (*Reader, error) {
+	must(r)
+	if maxDecompressed < 0 {
+		panic("decompressed size limit cannot be negative")
+	}
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(3)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var dr io.Reader
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b, 8}):
+		dr, err = newGzipReader(br, true)
+	case isZlib(magic):
+		dr, err = zlib.NewReader(br)
+	default:
+		return nil, fmt.Errorf("%w: not a gzip or zlib stream", ErrBadMagic)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(&boundedInflateReader{r: dr, left: maxDecompressed, limit: maxDecompressed}), nil
+}
+
+type boundedInflateReader struct {
+	r           io.Reader
+	left, limit int64
+}
+
+func (b *boundedInflateReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if b.left == 0 {
+		//at the limit, anything more is too much
+		var one [1]byte
+		n, err := b.r.Read(one[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: more than %d bytes", ErrDecompressedTooLarge, b.limit)
+		}
+		return 0, err
+	}
+
+	n, err := b.r.Read(p[:min(int64(len(p)), b.left)])
+	b.left -= int64(n)
+	return n, err
+}
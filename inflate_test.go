@@ -0,0 +1,58 @@
+package simple
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func zlibbed(s string) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestBoundedInflateReader(t *testing.T) {
+	const want = "Hello, World!"
+	for name, in := range map[string][]byte{"gzip": gzipped(t, want), "zlib": zlibbed(want)} {
+		//exactly at the limit is allowed
+		for _, limit := range []int64{int64(len(want)), 1 << 20} {
+			r, err := NewBoundedInflateReader(bytes.NewReader(in), limit)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if got, err := io.ReadAll(r); err != nil || string(got) != want {
+				t.Errorf("%s limit %d: got %q, %v", name, limit, got, err)
+			}
+		}
+	}
+}
+
+func TestBoundedInflateReaderBomb(t *testing.T) {
+	//8MiB of zeros compresses to about 16KiB
+	bomb := strings.Repeat("\x00", 8<<20)
+	for name, in := range map[string][]byte{"gzip": gzipped(t, bomb), "zlib": zlibbed(bomb)} {
+		if len(in) > 32<<10 {
+			t.Fatalf("%s: %d bytes compressed", name, len(in))
+		}
+		r, err := NewBoundedInflateReader(bytes.NewReader(in), 1<<20)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		n, err := io.Copy(io.Discard, r)
+		if n != 1<<20 || !errors.Is(err, ErrDecompressedTooLarge) {
+			t.Errorf("%s: got %d, %v", name, n, err)
+		}
+	}
+}
+
+func TestBoundedInflateReaderNotCompressed(t *testing.T) {
+	if _, err := NewBoundedInflateReader(strings.NewReader("plain"), 10); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("got %v", err)
+	}
+}
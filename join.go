@@ -0,0 +1,56 @@
+package simple
+
+import "io"
+
+//JoinReaders returns a Reader of the concatenation of readers,
+//with sep between each reader and the next, as by bytes.Join.
+//
+//Every reader is separated from the next, even if it is empty,
+//so an empty reader in the middle results in two adjacent separators.
+//Neither a single reader nor no readers are given any separator.
+//
+//The end of each reader but the last, and any io.EOF returned with it,
+//is not returned; the separator is returned in its place.
+//Other errors from the readers are returned as they occur,
+//as by io.MultiReader.
+func JoinReaders(sep []byte, readers ...io.Reader) *Reader {
+	for _, r := range readers {
+		must(r)
+	}
+	return NewReader(&joinReader{
+		sep:     append([]byte(nil), sep...),
+		readers: append([]io.Reader(nil), readers...),
+	})
+}
+
+type joinReader struct {
+	sep     []byte
+	readers []io.Reader
+	pending []byte //the part of sep still to be returned
+}
+
+func (j *joinReader) Read(p []byte) (int, error) {
+	for {
+		if len(j.pending) > 0 {
+			n := copy(p, j.pending)
+			j.pending = j.pending[n:]
+			return n, nil
+		}
+		if len(j.readers) == 0 {
+			return 0, io.EOF
+		}
+
+		n, err := j.readers[0].Read(p)
+		if err == io.EOF {
+			j.readers[0] = nil
+			j.readers = j.readers[1:]
+			if len(j.readers) > 0 {
+				j.pending = j.sep
+			}
+			err = nil
+		}
+		if n > 0 || err != nil || len(p) == 0 {
+			return n, err
+		}
+	}
+}
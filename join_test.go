@@ -0,0 +1,58 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestJoinReaders(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"a"}, "a"},
+		{[]string{""}, ""},
+		{[]string{"a", "bc", "def"}, "a, bc, def"},
+		{[]string{"a", "", "", "b"}, "a, , , b"},
+		{[]string{"", "a", ""}, ", a, "},
+	}
+	for _, tt := range tests {
+		for _, size := range []int{1, 3, 64} {
+			var readers []io.Reader
+			for _, p := range tt.parts {
+				//io.EOF comes with the last of the data
+				readers = append(readers, iotest.DataErrReader(strings.NewReader(p)))
+			}
+			r := JoinReaders([]byte(", "), readers...)
+			var got []byte
+			buf := make([]byte, size)
+			for {
+				p, err := Read(r, buf)
+				got = append(got, p...)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if string(got) != tt.want {
+				t.Errorf("%q read by %d: got %q want %q", tt.parts, size, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestJoinReadersError(t *testing.T) {
+	r := JoinReaders([]byte("|"), &dataErr{"ab", errTruncated}, strings.NewReader("cd"))
+	if got, err := io.ReadAll(r); string(got) != "ab" || err != errTruncated {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	//as with io.MultiReader, the reader is not abandoned
+	if got, err := io.ReadAll(r); string(got) != "|cd" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
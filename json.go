@@ -0,0 +1,163 @@
+package simple
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrMalformedJSON is returned, wrapped with more detail, by ReadJSONValue
+//when the stream does not hold a well-formed JSON value.
+var ErrMalformedJSON = errors.New("simple: malformed JSON value")
+
+//ReadJSONValue reads exactly one JSON value from r,
+//skipping any whitespace before it,
+//and returns the raw bytes of the value.
+//
+//Values may span any number of reads of r.
+//ReadJSONValue returns io.EOF only if r ends before the value begins.
+//If it ends within the value, ReadJSONValue returns io.ErrUnexpectedEOF.
+//
+//r is read one byte at a time, so nothing after the value is consumed
+//and successive calls read successive values of a stream of
+//concatenated JSON values.
+//The exception is a bare number, true, false, or null,
+//whose end can only be seen by reading the byte after it:
+//if r is an io.ByteScanner, such as a *bufio.Reader, that byte is unread,
+//otherwise it is consumed.
+//That only matters if the value is not followed by whitespace.
+func ReadJSONValue(r io.Reader) ([]byte, error) {
+	must(r)
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: NewReader(r)}
+	}
+
+	b, err := br.ReadByte()
+	for err == nil && isJSONSpace(b) {
+		b, err = br.ReadByte()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value := []byte{b}
+	switch {
+	case b == '{' || b == '[':
+		value, err = readJSONComposite(br, value)
+	case b == '"':
+		value, err = readJSONString(br, value)
+	case isJSONScalar(b):
+		value, err = readJSONScalar(br, value)
+	}
+	if err != nil {
+		return nil, noEOF(err)
+	}
+
+	if !json.Valid(value) {
+		return nil, fmt.Errorf("%w: %.32q", ErrMalformedJSON, value)
+	}
+	return value, nil
+}
+
+//readJSONComposite reads the rest of an object or array
+//by matching brackets outside of strings.
+func readJSONComposite(br io.ByteReader, value []byte) ([]byte, error) {
+	closers := []byte{jsonCloser(value[0])}
+	for len(closers) > 0 {
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		value = append(value, b)
+		switch b {
+		case '{', '[':
+			closers = append(closers, jsonCloser(b))
+		case '}', ']':
+			if b != closers[len(closers)-1] {
+				return nil, fmt.Errorf("%w: mismatched %q", ErrMalformedJSON, b)
+			}
+			closers = closers[:len(closers)-1]
+		case '"':
+			if value, err = readJSONString(br, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+func jsonCloser(open byte) byte {
+	if open == '{' {
+		return '}'
+	}
+	return ']'
+}
+
+//readJSONString reads the rest of a string through its closing quote.
+func readJSONString(br io.ByteReader, value []byte) ([]byte, error) {
+	for escaped := false; ; {
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		value = append(value, b)
+		switch {
+		case escaped:
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			return value, nil
+		}
+	}
+}
+
+//readJSONScalar reads the rest of a number or literal.
+func readJSONScalar(br io.ByteReader, value []byte) ([]byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return value, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isJSONScalar(b) {
+			if bs, ok := br.(io.ByteScanner); ok {
+				return value, bs.UnreadByte()
+			}
+			return value, nil
+		}
+		value = append(value, b)
+	}
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+//isJSONScalar reports whether b may appear in a number or literal.
+func isJSONScalar(b byte) bool {
+	return 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || b == '-' || b == '+' || b == '.' || b == 'E'
+}
+
+//byteReader reads one byte at a time so nothing is read past what is needed.
+type byteReader struct {
+	r *Reader
+	b [1]byte
+}
+
+func (br *byteReader) ReadByte() (byte, error) {
+	for i := 0; i < maxNoProgress; i++ {
+		n, err := br.r.Read(br.b[:])
+		if n == 1 {
+			return br.b[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return 0, io.ErrNoProgress
+}
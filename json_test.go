@@ -0,0 +1,75 @@
+package simple
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadJSONValue(t *testing.T) {
+	values := []string{
+		`{"a": [1, {"b": "}]\"{"}], "c": {}}`,
+		`[[], [[]], {"x": null}]`,
+		`"just a \"string\" \\"`,
+		`-12.5e+3`,
+		`true`,
+		`null`,
+		`{}`,
+	}
+	in := " \n" + strings.Join(values, "\n") + "\n"
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := wrap(strings.NewReader(in))
+		for _, want := range values {
+			got, err := ReadJSONValue(r)
+			if err != nil || string(got) != want {
+				t.Fatalf("got %q, %v want %q", got, err, want)
+			}
+		}
+		if got, err := ReadJSONValue(r); err != io.EOF {
+			t.Errorf("got %q, %v want io.EOF", got, err)
+		}
+	}
+}
+
+func TestReadJSONValuePosition(t *testing.T) {
+	//a ByteScanner gets back the byte ending a bare number
+	r := bufio.NewReader(strings.NewReader(`12[3]"x"`))
+	for _, want := range []string{`12`, `[3]`, `"x"`} {
+		if got, err := ReadJSONValue(r); err != nil || string(got) != want {
+			t.Fatalf("got %q, %v want %q", got, err, want)
+		}
+	}
+
+	//anything else is left positioned right after the value
+	sr := strings.NewReader(`{"a":1}rest`)
+	if got, err := ReadJSONValue(NewReader(sr)); err != nil || string(got) != `{"a":1}` {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if rest, _ := io.ReadAll(sr); string(rest) != "rest" {
+		t.Errorf("left %q want %q", rest, "rest")
+	}
+}
+
+func TestReadJSONValueMalformed(t *testing.T) {
+	cases := []struct {
+		in   string
+		want error
+	}{
+		{`{"a": [1, 2}`, ErrMalformedJSON},
+		{`}`, ErrMalformedJSON},
+		{`tru`, ErrMalformedJSON},
+		{`1.2.3`, ErrMalformedJSON},
+		{`{"a": [1, 2`, io.ErrUnexpectedEOF},
+		{`"abc`, io.ErrUnexpectedEOF},
+		{"  \n", io.EOF},
+	}
+	for _, c := range cases {
+		if _, err := ReadJSONValue(strings.NewReader(c.in)); !errors.Is(err, c.want) {
+			t.Errorf("%q: got %v want %v", c.in, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+//JSONLines returns an iterator over the values of a stream of JSON lines,
+//each a single JSON value terminated by \n or \r\n,
+//or by the end of the stream.
+//
+//Each value is yielded, without the line ending, with a nil error.
+//Blank lines are skipped.
+//Lines may span any number of reads.
+//The first line that is not a well-formed JSON value stops the iteration
+//with an error wrapping ErrMalformedJSON that gives its line number.
+//Otherwise, the last pair yielded has a nil value and either io.EOF,
+//if the stream ended cleanly, or the error that stopped it.
+//
+//r is buffered, so if the iteration is stopped early,
+//more may have been read from r than has been yielded.
+func (r *Reader) JSONLines() iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		br := bufio.NewReader(r)
+		for n := 1; ; n++ {
+			line, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				yield(nil, err)
+				return
+			}
+
+			line = bytes.TrimSuffix(line, []byte("\n"))
+			line = bytes.TrimSuffix(line, []byte("\r"))
+			switch {
+			case len(bytes.TrimLeft(line, " \t\r\n")) == 0:
+				//blank
+			case !json.Valid(line):
+				yield(nil, fmt.Errorf("%w: line %d", ErrMalformedJSON, n))
+				return
+			case !yield(line, nil):
+				return
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package simple
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONLines(t *testing.T) {
+	const in = `{"a": 1}` + "\n" + `[1, "two\n"]` + "\r\n\n" + `"x"`
+	want := []string{`{"a": 1}`, `[1, "two\n"]`, `"x"`}
+	for i := 0; i <= len(in); i++ {
+		var got []string
+		var end error
+		for v, err := range NewScriptedReader([]byte(in), []int{i, 3}).JSONLines() {
+			if err != nil {
+				end = err
+				break
+			}
+			got = append(got, string(v))
+		}
+		if end != io.EOF || strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("split at %d: got %q, %v", i, got, end)
+		}
+	}
+}
+
+func TestJSONLinesMalformed(t *testing.T) {
+	const in = "1\n\n{\"a\":}\n3\n"
+	var got []json.RawMessage
+	var end error
+	for v, err := range NewReader(strings.NewReader(in)).JSONLines() {
+		if err != nil {
+			end = err
+			break
+		}
+		got = append(got, v)
+	}
+	if len(got) != 1 || string(got[0]) != "1" {
+		t.Errorf("got %q", got)
+	}
+	if !errors.Is(end, ErrMalformedJSON) || !strings.Contains(end.Error(), "line 3") {
+		t.Errorf("got %v", end)
+	}
+}
+
+func TestJSONLinesError(t *testing.T) {
+	r := NewReader(&dataErr{"1\n2", errTruncated})
+	var got []string
+	var end error
+	for v, err := range r.JSONLines() {
+		if err != nil {
+			end = err
+			continue
+		}
+		got = append(got, string(v))
+	}
+	//the incomplete last line is not yielded
+	if strings.Join(got, "|") != "1" || end != errTruncated {
+		t.Errorf("got %q, %v", got, end)
+	}
+}
@@ -0,0 +1,92 @@
+package simple
+
+import "io"
+
+//LazyMultiReader is a Reader that concatenates readers
+//that are only created when they are needed.
+type LazyMultiReader struct {
+	*Reader
+	l *lazyMultiReader
+}
+
+//NewLazyMultiReader returns a LazyMultiReader of the concatenation
+//of the readers returned by factories.
+//
+//Each factory is called, in order, only once the reader before it is
+//exhausted, so that many sources, like files, need not be open at once.
+//A reader that is an io.Closer is closed once it is exhausted.
+//
+//If a factory fails, its error is returned after all the data before it,
+//and by every later Read, without calling any later factory.
+//Errors from the readers themselves are returned as they occur,
+//as by io.MultiReader, and do not stop the LazyMultiReader.
+func NewLazyMultiReader(factories []func() (io.Reader, error)) *LazyMultiReader {
+	l := &lazyMultiReader{factories: append([]func() (io.Reader, error)(nil), factories...)}
+	return &LazyMultiReader{
+		Reader: NewReader(l),
+		l:      l,
+	}
+}
+
+//Close closes the current reader, if it is an io.Closer,
+//and stops the LazyMultiReader.
+//No later factories are called and all later Reads return ErrClosed.
+//
+//Close must not be called concurrently with Read.
+func (l *LazyMultiReader) Close() error {
+	return l.l.close()
+}
+
+type lazyMultiReader struct {
+	factories []func() (io.Reader, error)
+	cur       io.Reader
+	err       error //from a factory, or io.EOF or ErrClosed
+}
+
+func (l *lazyMultiReader) Read(p []byte) (int, error) {
+	for {
+		if l.err != nil {
+			return 0, l.err
+		}
+
+		if l.cur == nil {
+			if len(l.factories) == 0 {
+				l.err = io.EOF
+				continue
+			}
+			f := l.factories[0]
+			l.factories[0] = nil
+			l.factories = l.factories[1:]
+			if l.cur, l.err = f(); l.err != nil {
+				l.cur = nil
+				continue
+			}
+			must(l.cur)
+		}
+
+		n, err := l.cur.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+		err = nil
+		if c, ok := l.cur.(io.Closer); ok {
+			err = c.Close()
+		}
+		l.cur = nil
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (l *lazyMultiReader) close() error {
+	var err error
+	if c, ok := l.cur.(io.Closer); ok {
+		err = c.Close()
+	}
+	l.cur, l.factories = nil, nil
+	if l.err == nil || l.err == io.EOF {
+		l.err = ErrClosed
+	}
+	return err
+}
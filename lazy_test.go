@@ -0,0 +1,84 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLazyMultiReader(t *testing.T) {
+	var log []string
+	var srcs []*closeCounter
+	factory := func(s string) func() (io.Reader, error) {
+		return func() (io.Reader, error) {
+			log = append(log, "open "+s)
+			srcs = append(srcs, &closeCounter{Reader: strings.NewReader(s)})
+			return srcs[len(srcs)-1], nil
+		}
+	}
+	r := NewLazyMultiReader([]func() (io.Reader, error){factory("ab"), factory(""), factory("cd")})
+	if len(log) != 0 {
+		t.Fatalf("factories called early: %v", log)
+	}
+
+	p := make([]byte, 2)
+	if n, err := r.Read(p); n != 2 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if fmt.Sprint(log) != "[open ab]" {
+		t.Errorf("got %v after first read", log)
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "cd" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if fmt.Sprint(log) != "[open ab open  open cd]" {
+		t.Errorf("got %v", log)
+	}
+	for i, src := range srcs {
+		if src.closes != 1 {
+			t.Errorf("reader %d closed %d times", i, src.closes)
+		}
+	}
+}
+
+func TestLazyMultiReaderFactoryError(t *testing.T) {
+	errOpen := errors.New("cannot open")
+	calls := 0
+	r := NewLazyMultiReader([]func() (io.Reader, error){
+		func() (io.Reader, error) { calls++; return strings.NewReader("ab"), nil },
+		func() (io.Reader, error) { calls++; return nil, errOpen },
+		func() (io.Reader, error) { calls++; return strings.NewReader("cd"), nil },
+	})
+	got, err := io.ReadAll(r)
+	if err != errOpen || string(got) != "ab" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := r.Read(make([]byte, 1)); err != errOpen {
+		t.Errorf("got %v want %v", err, errOpen)
+	}
+	if calls != 2 {
+		t.Errorf("%d factories called, want 2", calls)
+	}
+}
+
+func TestLazyMultiReaderClose(t *testing.T) {
+	src := &closeCounter{Reader: strings.NewReader("abc")}
+	r := NewLazyMultiReader([]func() (io.Reader, error){
+		func() (io.Reader, error) { return src, nil },
+		func() (io.Reader, error) { t.Error("factory called after Close"); return nil, nil },
+	})
+	r.Read(make([]byte, 1))
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if src.closes != 1 {
+		t.Errorf("closed %d times", src.closes)
+	}
+	if _, err := r.Read(make([]byte, 1)); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
@@ -0,0 +1,63 @@
+package simple
+
+import "io"
+
+//LineEnding is a style of line ending written by NewLineEndingReader.
+type LineEnding int
+
+const (
+	LF   LineEnding = iota //\n, as on Unix
+	CRLF                   //\r\n, as on Windows and in many protocols
+	CR                     //\r, as on classic Mac OS
+)
+
+func (l LineEnding) bytes() []byte {
+	switch l {
+	case LF:
+		return []byte{'\n'}
+	case CRLF:
+		return []byte{'\r', '\n'}
+	case CR:
+		return []byte{'\r'}
+	}
+	panic("unknown LineEnding")
+}
+
+//NewLineEndingReader returns a Reader that replaces every line ending in r,
+//whether \n, \r\n, or a lone \r, with style.
+//
+//A \r\n may be split across reads of r.
+//
+//NewLineEndingReader panics if style is unknown.
+func NewLineEndingReader(r io.Reader, style LineEnding) *Reader {
+	return newTransformReader(r, &lineEndingTransform{eol: style.bytes()})
+}
+
+type lineEndingTransform struct {
+	eol []byte
+	cr  bool //whether the last byte was \r
+}
+
+func (l *lineEndingTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch b {
+		case '\r':
+			out = append(out, l.eol...)
+			l.cr = true
+		case '\n':
+			//the \n of \r\n has already been written
+			if !l.cr {
+				out = append(out, l.eol...)
+			}
+			l.cr = false
+		default:
+			out = append(out, b)
+			l.cr = false
+		}
+	}
+	return out, nil
+}
+
+func (l *lineEndingTransform) flush(out []byte) ([]byte, error) {
+	return out, nil
+}
@@ -0,0 +1,40 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineEndingReader(t *testing.T) {
+	inputs := map[string]string{
+		"LF":    "a\nb\n\nc",
+		"CRLF":  "a\r\nb\r\n\r\nc",
+		"CR":    "a\rb\r\rc",
+		"mixed": "a\nb\r\r\nc", //one of each
+	}
+	want := map[LineEnding]string{
+		LF:   "a\nb\n\nc",
+		CRLF: "a\r\nb\r\n\r\nc",
+		CR:   "a\rb\r\rc",
+	}
+	for name, in := range inputs {
+		for style, w := range want {
+			for i := 0; i <= len(in); i++ {
+				r := NewLineEndingReader(NewScriptedReader([]byte(in), []int{i, 1}), style)
+				got, err := io.ReadAll(r)
+				if err != nil || string(got) != w {
+					t.Errorf("%s to %d split at %d: got %q, %v want %q", name, style, i, got, err, w)
+				}
+			}
+		}
+	}
+}
+
+func TestLineEndingReaderSplitCRLF(t *testing.T) {
+	//\r ends one read and \n begins the next
+	r := NewLineEndingReader(io.MultiReader(strings.NewReader("a\r"), strings.NewReader("\nb\r")), LF)
+	if got, err := io.ReadAll(r); err != nil || string(got) != "a\nb\n" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,68 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+//ErrTooManyLines is returned by a Reader from NewLineLimitCountReader
+//when the stream has more lines than allowed.
+var ErrTooManyLines = errors.New("simple: too many lines")
+
+//NewLineLimitCountReader returns a Reader that delivers
+//the first maxLines lines of r,
+//then returns ErrTooManyLines if r has anything after them.
+//
+//Lines end with \n, and a last line without one counts as a line,
+//so a stream of exactly maxLines lines is delivered in full
+//whether or not it ends with \n.
+//Only a count is kept, so memory use does not depend on the input.
+//
+//NewLineLimitCountReader panics if maxLines < 0.
+func NewLineLimitCountReader(r io.Reader, maxLines int) *Reader {
+	return newLineLimitReader(r, maxLines, ErrTooManyLines)
+}
+
+//NewLineTruncateReader is like NewLineLimitCountReader
+//except that it returns io.EOF after maxLines lines
+//rather than ErrTooManyLines.
+func NewLineTruncateReader(r io.Reader, maxLines int) *Reader {
+	return newLineLimitReader(r, maxLines, io.EOF)
+}
+
+func newLineLimitReader(r io.Reader, maxLines int, over error) *Reader {
+	must(r)
+	if maxLines < 0 {
+		panic("max lines cannot be negative")
+	}
+	return NewReader(&lineLimitReader{r: r, left: maxLines, over: over})
+}
+
+type lineLimitReader struct {
+	r    io.Reader
+	left int   //lines that may still be completed
+	over error //returned after the last line allowed
+	err  error
+}
+
+func (l *lineLimitReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	n, err := l.r.Read(p)
+	for i := 0; i < n; {
+		if l.left == 0 {
+			l.err = l.over
+			return i, l.err
+		}
+		j := bytes.IndexByte(p[i:n], '\n')
+		if j < 0 {
+			break
+		}
+		i += j + 1
+		l.left--
+	}
+	return n, err
+}
@@ -0,0 +1,38 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineLimitCountReader(t *testing.T) {
+	cases := []struct {
+		in, want string
+		err      error
+	}{
+		{"a\nb\nc\n", "a\nb\nc\n", nil},
+		{"a\nb\nc", "a\nb\nc", nil},
+		{"a\nb\nc\nd", "a\nb\nc\n", ErrTooManyLines},
+		{"a\nb\nc\n\n", "a\nb\nc\n", ErrTooManyLines},
+		{"", "", nil},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewLineLimitCountReader(wrap(strings.NewReader(c.in)), 3))
+			if err != c.err || string(got) != c.want {
+				t.Errorf("%q: got %q, %v want %q, %v", c.in, got, err, c.want, c.err)
+			}
+
+			got, err = io.ReadAll(NewLineTruncateReader(wrap(strings.NewReader(c.in)), 3))
+			if err != nil || string(got) != c.want {
+				t.Errorf("%q truncated: got %q, %v want %q", c.in, got, err, c.want)
+			}
+		}
+	}
+
+	if got, err := io.ReadAll(NewLineLimitCountReader(strings.NewReader("a"), 0)); err != ErrTooManyLines || len(got) != 0 {
+		t.Errorf("zero lines: got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,83 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+//LineRecordReader is a Reader that numbers the lines it reads.
+//
+//It is not related to NewRecordReader, which records a stream for replay.
+type LineRecordReader struct {
+	*Reader
+	l *lineRecordReader
+}
+
+//NewLineRecordReader wraps r in a LineRecordReader.
+//
+//r is buffered, so more may be read from r than has been delivered.
+func NewLineRecordReader(r io.Reader) *LineRecordReader {
+	must(r)
+	l := &lineRecordReader{br: bufio.NewReader(r)}
+	return &LineRecordReader{
+		Reader: NewReader(l),
+		l:      l,
+	}
+}
+
+//ReadRecord returns the next line, without its \n or \r\n,
+//and its 1-based record number.
+//
+//Lines may span any number of reads of the wrapped io.Reader.
+//A last line without a line ending is a record.
+//ReadRecord returns io.EOF, with a record number of 0,
+//only once every record has been returned.
+//
+//ReadRecord may be mixed with Read:
+//lines delivered by Read are counted, and a line partly delivered by Read
+//has the rest of it returned by ReadRecord.
+func (l *LineRecordReader) ReadRecord() ([]byte, int, error) {
+	if err := l.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	line, err := l.l.br.ReadBytes('\n')
+	if len(line) == 0 {
+		return nil, 0, l.surface(err)
+	}
+	l.SetError(err)
+
+	if !l.l.mid {
+		l.l.records++
+	}
+	l.l.mid = false
+	line = bytes.TrimSuffix(line, []byte{'\n'})
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	return line, l.l.records, nil
+}
+
+//RecordNumber returns the number of records read so far,
+//which is the number of the last record returned by ReadRecord,
+//unless Read has been used since.
+//A record partly delivered by Read is counted.
+func (l *LineRecordReader) RecordNumber() int {
+	return l.l.records
+}
+
+type lineRecordReader struct {
+	br      *bufio.Reader
+	records int
+	mid     bool //whether a record is partly delivered
+}
+
+func (l *lineRecordReader) Read(p []byte) (int, error) {
+	n, err := l.br.Read(p)
+	for _, b := range p[:n] {
+		if !l.mid {
+			l.records++
+		}
+		l.mid = b != '\n'
+	}
+	return n, err
+}
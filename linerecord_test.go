@@ -0,0 +1,56 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineRecordReader(t *testing.T) {
+	const in = "one\r\ntwo\n\nfour"
+	want := []string{"one", "two", "", "four"}
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewLineRecordReader(wrap(strings.NewReader(in)))
+		for i, w := range want {
+			rec, n, err := r.ReadRecord()
+			if err != nil || string(rec) != w || n != i+1 {
+				t.Fatalf("got %q, %d, %v want %q, %d", rec, n, err, w, i+1)
+			}
+			if r.RecordNumber() != i+1 {
+				t.Errorf("RecordNumber %d want %d", r.RecordNumber(), i+1)
+			}
+		}
+		if rec, n, err := r.ReadRecord(); err != io.EOF || rec != nil || n != 0 {
+			t.Errorf("got %q, %d, %v want io.EOF", rec, n, err)
+		}
+	}
+}
+
+func TestLineRecordReaderMixed(t *testing.T) {
+	r := NewLineRecordReader(strings.NewReader("one\ntwo\nthree\nfour\n"))
+	if p, err := Read(r, make([]byte, 6)); err != nil || string(p) != "one\ntw" {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	if n := r.RecordNumber(); n != 2 {
+		t.Errorf("RecordNumber %d want 2", n)
+	}
+	for i, w := range []string{"o", "three", "four"} {
+		rec, n, err := r.ReadRecord()
+		if err != nil || string(rec) != w || n != i+2 {
+			t.Errorf("got %q, %d, %v want %q, %d", rec, n, err, w, i+2)
+		}
+	}
+}
+
+func TestLineRecordReaderError(t *testing.T) {
+	r := NewLineRecordReader(&dataErr{"one\ntwo", errTruncated})
+	for _, w := range []string{"one", "two"} {
+		if rec, _, err := r.ReadRecord(); err != nil || string(rec) != w {
+			t.Fatalf("got %q, %v want %q", rec, err, w)
+		}
+	}
+	if _, _, err := r.ReadRecord(); err != errTruncated {
+		t.Errorf("got %v want %v", err, errTruncated)
+	}
+}
@@ -0,0 +1,103 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrMalformedLVT is returned, wrapped with more detail,
+//by LVTReader.ReadTLV for a record with an invalid length.
+var ErrMalformedLVT = errors.New("simple: malformed type-length-value record")
+
+//LVTReader is a Reader that can also read type-length-value records.
+type LVTReader struct {
+	*Reader
+
+	//MaxLength, if positive, limits the length of a value
+	//returned by ReadTLV.
+	//Longer values are rejected with an error wrapping ErrFrameTooLarge
+	//before they are read.
+	//
+	//NewLVTReader sets it to DefaultMaxLVT.
+	MaxLength int
+
+	order binary.ByteOrder
+}
+
+//DefaultMaxLVT is the default LVTReader.MaxLength.
+const DefaultMaxLVT = 1 << 20
+
+//NewLVTReader returns an LVTReader reading from r
+//whose long form lengths are in byteOrder.
+func NewLVTReader(r io.Reader, byteOrder binary.ByteOrder) *LVTReader {
+	return &LVTReader{
+		Reader:    NewReader(r),
+		MaxLength: DefaultMaxLVT,
+		order:     byteOrder,
+	}
+}
+
+//ReadTLV reads one record and returns its type and value.
+//
+//A record is a type byte, a length, and that many bytes of value.
+//A length under 128 is a single byte.
+//Otherwise the first byte is 0x80 plus the width of the length, 1 to 8,
+//and the length follows in that many bytes in the byte order of the
+//LVTReader, as in the long form lengths of ASN.1, which are big-endian.
+//
+//Nothing is read past the value, so ReadTLV may be mixed with Read.
+//
+//ReadTLV returns io.EOF only if the stream ends before the record begins.
+//If it ends within the record, ReadTLV returns io.ErrUnexpectedEOF.
+//A length of invalid width results in an error wrapping ErrMalformedLVT.
+func (l *LVTReader) ReadTLV() (typ uint8, value []byte, err error) {
+	var head [2]byte
+	n, err := io.ReadFull(l.Reader, head[:])
+	if err != nil {
+		if err == io.ErrUnexpectedEOF && n == 0 {
+			err = io.EOF
+		}
+		return 0, nil, err
+	}
+	typ = head[0]
+
+	size := uint64(head[1])
+	if size >= 0x80 {
+		width := int(size & 0x7f)
+		if width < 1 || width > 8 {
+			return typ, nil, fmt.Errorf("%w: length of %d bytes", ErrMalformedLVT, width)
+		}
+		var buf [8]byte
+		if _, err := io.ReadFull(l.Reader, buf[:width]); err != nil {
+			return typ, nil, noEOF(err)
+		}
+		size = lvtLength(l.order, buf[:width])
+	}
+	if l.MaxLength > 0 && size > uint64(l.MaxLength) {
+		return typ, nil, fmt.Errorf("%w: value of %d bytes, max %d", ErrFrameTooLarge, size, l.MaxLength)
+	}
+
+	//grow the value as it arrives rather than trusting size
+	value, err = io.ReadAll(io.LimitReader(l.Reader, int64(min(size, 1<<63-1))))
+	if err != nil {
+		return typ, nil, err
+	}
+	if uint64(len(value)) < size {
+		return typ, nil, io.ErrUnexpectedEOF
+	}
+	return typ, value, nil
+}
+
+//lvtLength decodes a length of any width from 1 to 8 bytes.
+func lvtLength(order binary.ByteOrder, b []byte) uint64 {
+	var buf [8]byte
+	//pad to 8 bytes on the most significant side
+	if order.Uint16([]byte{0, 1}) == 1 {
+		copy(buf[8-len(b):], b)
+	} else {
+		copy(buf[:], b)
+	}
+	return order.Uint64(buf[:])
+}
@@ -0,0 +1,71 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLVTReader(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	tests := map[binary.ByteOrder]string{
+		binary.BigEndian:    "\x01\x05hello" + "\x02\x00" + "\x03\x82\x01\x2c" + long + "\x04\x81\x02hi",
+		binary.LittleEndian: "\x01\x05hello" + "\x02\x00" + "\x03\x82\x2c\x01" + long + "\x04\x81\x02hi",
+	}
+	for order, in := range tests {
+		for i := 0; i <= 20; i++ {
+			l := NewLVTReader(NewScriptedReader([]byte(in), []int{i, 7}), order)
+			var got []string
+			for {
+				typ, value, err := l.ReadTLV()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("%v split at %d: %v", order, i, err)
+				}
+				got = append(got, fmt.Sprintf("%d:%d", typ, len(value)))
+				if typ == 1 && string(value) != "hello" || typ == 3 && string(value) != long {
+					t.Errorf("%v split at %d: type %d: got %q", order, i, typ, value)
+				}
+			}
+			if strings.Join(got, " ") != "1:5 2:0 3:300 4:2" {
+				t.Errorf("%v split at %d: got %v", order, i, got)
+			}
+		}
+	}
+}
+
+func TestLVTReaderMalformed(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want error
+	}{
+		"truncated type":   {"\x01", io.ErrUnexpectedEOF},
+		"truncated length": {"\x01\x82\x01", io.ErrUnexpectedEOF},
+		"truncated value":  {"\x01\x05hel", io.ErrUnexpectedEOF},
+		"zero width":       {"\x01\x80", ErrMalformedLVT},
+		"wide":             {"\x01\x89", ErrMalformedLVT},
+		"too long":         {"\x01\x84\x7f\xff\xff\xff", ErrFrameTooLarge},
+	}
+	for name, tt := range tests {
+		l := NewLVTReader(strings.NewReader("\x07\x01a"+tt.in), binary.BigEndian)
+		if typ, value, err := l.ReadTLV(); typ != 7 || string(value) != "a" || err != nil {
+			t.Fatalf("%s: got %d, %q, %v", name, typ, value, err)
+		}
+		if _, _, err := l.ReadTLV(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v want %v", name, err, tt.want)
+		}
+	}
+}
+
+func TestLVTReaderMaxLength(t *testing.T) {
+	l := NewLVTReader(strings.NewReader("\x01\x05hello"), binary.BigEndian)
+	l.MaxLength = 4
+	if _, _, err := l.ReadTLV(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("got %v", err)
+	}
+}
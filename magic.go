@@ -0,0 +1,125 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrBadMagic is matched, via errors.Is, by the *BadMagicError
+//returned from NewMagicReader.
+var ErrBadMagic = errors.New("simple: bad magic number")
+
+//BadMagicError reports that a stream did not begin with the expected
+//magic number.
+type BadMagicError struct {
+	//Got is what the stream began with,
+	//up to and including the first byte that differs from Want.
+	//It is shorter than Want if the stream ended early
+	//or a byte differed.
+	Got, Want []byte
+}
+
+func (e *BadMagicError) Error() string {
+	return fmt.Sprintf("%s: got %q want %q", ErrBadMagic, e.Got, e.Want)
+}
+
+//Unwrap returns ErrBadMagic.
+func (e *BadMagicError) Unwrap() error {
+	return ErrBadMagic
+}
+
+//WithKeepMagic makes the Reader returned by NewMagicReader
+//deliver the magic number before the rest of the stream,
+//rather than consume it.
+//It has no effect on other Readers.
+func WithKeepMagic() Option {
+	return func(r *Reader) {
+		r.keepMagic = true
+	}
+}
+
+//NewMagicReader reads len(magic) bytes from r and returns
+//a *BadMagicError if they are not magic.
+//Otherwise, it returns a Reader over the rest of r, configured by any opts.
+//
+//Each read of r is checked as it arrives,
+//so a mismatch is reported without waiting for the rest of the magic.
+//
+//Errors reading r, other than it ending before len(magic) bytes,
+//are returned as is.
+func NewMagicReader(r io.Reader, magic []byte, opts ...Option) (*Reader, error) {
+	must(r)
+	m := &magicReader{r: r}
+	sr := NewReader(m, opts...)
+	got, err := readMagic(m, magic)
+	if err != nil {
+		return nil, err
+	}
+	if sr.keepMagic {
+		m.pending = got
+	}
+	return sr, nil
+}
+
+//magicReader delivers any pending bytes,
+//then any error stored while reading the magic, then the rest of r.
+type magicReader struct {
+	r       io.Reader
+	pending []byte
+	err     error
+}
+
+func (m *magicReader) Read(p []byte) (int, error) {
+	if len(m.pending) > 0 {
+		n := copy(p, m.pending)
+		m.pending = m.pending[n:]
+		return n, nil
+	}
+	if m.err != nil {
+		err := m.err
+		m.err = nil
+		return 0, err
+	}
+	return m.r.Read(p)
+}
+
+//readMagic reads exactly len(magic) bytes from m.r,
+//stopping at the first byte that is not magic.
+func readMagic(m *magicReader, magic []byte) ([]byte, error) {
+	got := make([]byte, 0, len(magic))
+	bad := func() error {
+		return &BadMagicError{
+			Got:  got,
+			Want: append([]byte(nil), magic...),
+		}
+	}
+	for stalls := 0; len(got) < len(magic); {
+		start := len(got)
+		n, err := m.r.Read(got[start:cap(got)])
+		got = got[:start+n]
+		for i := start; i < len(got); i++ {
+			if got[i] != magic[i] {
+				got = got[:i+1]
+				return nil, bad()
+			}
+		}
+
+		switch {
+		case err == nil && n == 0:
+			if stalls++; stalls >= maxNoProgress {
+				return nil, io.ErrNoProgress
+			}
+		case err == nil:
+			stalls = 0
+		case len(got) == len(magic):
+			//returned after the magic
+			m.err = err
+		case err == io.EOF:
+			return nil, bad()
+		default:
+			return nil, err
+		}
+	}
+	return got, nil
+}
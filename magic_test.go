@@ -0,0 +1,78 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMagicReader(t *testing.T) {
+	magic := []byte("\x89PNG")
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r, err := NewMagicReader(wrap(strings.NewReader("\x89PNG..data")), magic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := io.ReadAll(r); err != nil || string(got) != "..data" {
+			t.Errorf("got %q, %v", got, err)
+		}
+
+		r, err = NewMagicReader(wrap(strings.NewReader("\x89PNG..data")), magic, WithKeepMagic())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := io.ReadAll(r); err != nil || string(got) != "\x89PNG..data" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//the stream may be exactly the magic
+	b := NewBasic("\x89PNG")
+	r, err := NewMagicReader(&b, magic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AssertEOF(r); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMagicReaderBad(t *testing.T) {
+	magic := []byte("\x89PNG")
+	cases := []struct{ in, got string }{
+		{"GIF89a", "G"},
+		{"\x89PNX", "\x89PNX"},
+		{"\x89PN", "\x89PN"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		_, err := NewMagicReader(iotest.OneByteReader(strings.NewReader(c.in)), magic)
+		var be *BadMagicError
+		if !errors.Is(err, ErrBadMagic) || !errors.As(err, &be) {
+			t.Fatalf("%q: got %v want ErrBadMagic", c.in, err)
+		}
+		if string(be.Got) != c.got || string(be.Want) != string(magic) {
+			t.Errorf("%q: got %q/%q", c.in, be.Got, be.Want)
+		}
+	}
+
+	if _, err := NewMagicReader(iotest.ErrReader(errTruncated), magic); err != errTruncated {
+		t.Errorf("got %v want %v", err, errTruncated)
+	}
+}
+
+func TestMagicReaderImmediate(t *testing.T) {
+	//the stream would block after its first byte
+	calls := 0
+	r := readFunc(func(p []byte) (int, error) {
+		if calls++; calls > 1 {
+			t.Fatal("read past the first byte that differs")
+		}
+		return copy(p, "G"), nil
+	})
+	if _, err := NewMagicReader(r, []byte("\x89PNG")); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("got %v want ErrBadMagic", err)
+	}
+}
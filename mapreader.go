@@ -0,0 +1,44 @@
+package simple
+
+import "io"
+
+//NewMapReader returns a Reader that delivers the bytes of r
+//each replaced by mapping applied to it.
+//
+//mapping is applied in place, in the buffer passed to Read,
+//to exactly the bytes read into it,
+//including those returned along with an error.
+func NewMapReader(r io.Reader, mapping func(byte) byte) *Reader {
+	must(r)
+	return NewReader(&mapReader{r: r, mapping: mapping})
+}
+
+//NewROT13Reader returns a Reader that delivers the bytes of r
+//with ASCII letters rotated 13 places through the alphabet.
+//Applying it twice restores the original.
+func NewROT13Reader(r io.Reader) *Reader {
+	return NewMapReader(r, rot13)
+}
+
+func rot13(b byte) byte {
+	switch {
+	case 'a' <= b && b <= 'z':
+		return 'a' + (b-'a'+13)%26
+	case 'A' <= b && b <= 'Z':
+		return 'A' + (b-'A'+13)%26
+	}
+	return b
+}
+
+type mapReader struct {
+	r       io.Reader
+	mapping func(byte) byte
+}
+
+func (m *mapReader) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	for i, b := range p[:n] {
+		p[i] = m.mapping(b)
+	}
+	return n, err
+}
@@ -0,0 +1,39 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestROT13Reader(t *testing.T) {
+	const in = "Hello, World! 123 xyz ABC"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		once, err := io.ReadAll(NewROT13Reader(wrap(strings.NewReader(in))))
+		if err != nil || string(once) != "Uryyb, Jbeyq! 123 klm NOP" {
+			t.Errorf("got %q, %v", once, err)
+		}
+		twice, err := io.ReadAll(NewROT13Reader(NewROT13Reader(wrap(strings.NewReader(in)))))
+		if err != nil || string(twice) != in {
+			t.Errorf("got %q, %v", twice, err)
+		}
+	}
+}
+
+func TestMapReaderDeferredError(t *testing.T) {
+	r := NewMapReader(&dataErr{"abc", errTruncated}, func(b byte) byte { return b - 'a' + 'A' })
+	p := make([]byte, 10)
+	got, err := Read(r, p)
+	if err != nil || string(got) != "ABC" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if _, err := r.Read(p); err != errTruncated {
+		t.Errorf("got %v want %v", err, errTruncated)
+	}
+	//the rest of the buffer is untouched
+	if !bytes.Equal(p[3:], make([]byte, 7)) {
+		t.Errorf("buffer is %q", p)
+	}
+}
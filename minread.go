@@ -0,0 +1,67 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrShortRead is returned, wrapped with more detail,
+//by a Reader from NewMinReadReader when r returns too few bytes.
+var ErrShortRead = errors.New("simple: short read")
+
+//NewMinReadReader returns a Reader that flags reads of r
+//that return fewer than least bytes into a buffer of at least least bytes.
+//It is meant for testing that an io.Reader is not misbehaving or throttled.
+//
+//The last read before io.EOF may legitimately be short,
+//but that can only be known once r returns io.EOF.
+//So a short read is delivered as is,
+//and if a later read of r returns data or an error other than io.EOF,
+//the Reader returns an error wrapping ErrShortRead
+//after any data from that read, and from then on.
+//An error from that read is wrapped along with ErrShortRead.
+//A short read that comes with an error is never flagged.
+//
+//Short reads are only reported, never hidden:
+//the Reader does not read again to fill the buffer, as io.ReadFull does.
+//Reads returning 0, nil are not counted;
+//see NewStallDetectorReader for those.
+//
+//NewMinReadReader panics if least < 1.
+func NewMinReadReader(r io.Reader, least int) *Reader {
+	must(r)
+	if least < 1 {
+		panic("minimum read must be positive")
+	}
+	return NewReader(&minReadReader{r: r, least: least})
+}
+
+type minReadReader struct {
+	r     io.Reader
+	least int
+	short int //length of an unexcused short read, or 0
+	err   error
+}
+
+func (m *minReadReader) Read(p []byte) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	n, err := m.r.Read(p)
+	if m.short > 0 && (n > 0 || err != nil && err != io.EOF) {
+		m.err = fmt.Errorf("%w: read %d bytes, want at least %d", ErrShortRead, m.short, m.least)
+		if err != nil && err != io.EOF {
+			m.err = fmt.Errorf("%w: %w", m.err, err)
+		}
+		return n, m.err
+	}
+	if n > 0 {
+		m.short = 0
+		if err == nil && n < m.least && len(p) >= m.least {
+			m.short = n
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,90 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMinReadReader(t *testing.T) {
+	//only the last read is short
+	r := NewMinReadReader(strings.NewReader("0123456789"), 4)
+	got, err := Read(r, make([]byte, 4))
+	for err == nil {
+		var p []byte
+		p, err = Read(r, make([]byte, 4))
+		got = append(got, p...)
+	}
+	if err != io.EOF || string(got) != "0123456789" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	//a short final read with io.EOF
+	r = NewMinReadReader(&dataErr{"0123456789", io.EOF}, 4)
+	if got, err := io.ReadAll(&fixedRead{r, 4}); err != nil || string(got) != "0123456789" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestMinReadReaderShort(t *testing.T) {
+	r := NewMinReadReader(iotest.OneByteReader(strings.NewReader("0123456789")), 4)
+	p := make([]byte, 8)
+	if n, err := r.Read(p); n != 1 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	//the data that shows the read was short is not lost
+	if n, err := r.Read(p); n != 1 || err != nil || p[0] != '1' {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if n, err := r.Read(p); n != 0 || !errors.Is(err, ErrShortRead) {
+		t.Fatalf("got %d, %v want ErrShortRead", n, err)
+	}
+	if _, err := r.Read(p); !errors.Is(err, ErrShortRead) {
+		t.Errorf("got %v want ErrShortRead", err)
+	}
+
+	//small buffers are not held to the minimum
+	r = NewMinReadReader(strings.NewReader("0123456789"), 4)
+	if got, err := io.ReadAll(iotest.OneByteReader(r)); err != nil || string(got) != "0123456789" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestMinReadReaderShortThenNothing(t *testing.T) {
+	reads := []dataErr{{"ab", nil}, {"", nil}, {"", nil}, {"", io.EOF}}
+	r := NewMinReadReader(readFunc(func(p []byte) (int, error) {
+		d := reads[0]
+		reads = reads[1:]
+		return copy(p, d.data), d.err
+	}), 4)
+	//reads of 0, nil neither count as data after the short read
+	//nor excuse it
+	if got, err := io.ReadAll(r); string(got) != "ab" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestMinReadReaderShortThenError(t *testing.T) {
+	reads := []dataErr{{"ab", nil}, {"", errTruncated}}
+	r := NewMinReadReader(readFunc(func(p []byte) (int, error) {
+		d := reads[0]
+		reads = reads[1:]
+		return copy(p, d.data), d.err
+	}), 4)
+	got, err := io.ReadAll(r)
+	if string(got) != "ab" || !errors.Is(err, ErrShortRead) || !errors.Is(err, errTruncated) {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+//fixedRead reads from r into buffers of at most n bytes.
+type fixedRead struct {
+	r io.Reader
+	n int
+}
+
+func (f *fixedRead) Read(p []byte) (int, error) {
+	return f.r.Read(p[:min(len(p), f.n)])
+}
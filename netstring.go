@@ -0,0 +1,113 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+//ErrMalformedNetstring is returned, wrapped with more detail,
+//for input that is not a valid netstring.
+var ErrMalformedNetstring = errors.New("simple: malformed netstring")
+
+//NetstringCodec is a Codec for netstrings, as in "5:hello,".
+type NetstringCodec struct {
+	//MaxLength, if positive, limits the length of a payload.
+	//Longer payloads are rejected with an error wrapping ErrFrameTooLarge
+	//before they are read.
+	MaxLength int
+}
+
+//Decode reads one netstring from src.
+func (n NetstringCodec) Decode(src *Reader) ([]byte, error) {
+	return readNetstring(src, n.MaxLength)
+}
+
+//NetstringReader is a Reader that can also read netstrings.
+type NetstringReader struct {
+	*Reader
+
+	//MaxLength, if positive, limits the length of a payload
+	//returned by ReadNetstring.
+	//Longer payloads are rejected with an error wrapping ErrFrameTooLarge
+	//before they are read.
+	//
+	//NewNetstringReader sets it to DefaultMaxNetstring.
+	MaxLength int
+}
+
+//DefaultMaxNetstring is the default NetstringReader.MaxLength.
+const DefaultMaxNetstring = 1 << 20
+
+//NewNetstringReader returns a NetstringReader reading from r.
+func NewNetstringReader(r io.Reader) *NetstringReader {
+	return &NetstringReader{
+		Reader:    NewReader(r),
+		MaxLength: DefaultMaxNetstring,
+	}
+}
+
+//ReadNetstring reads one netstring, as in "5:hello,", and returns its payload.
+//
+//Nothing is read past the trailing comma,
+//so ReadNetstring may be mixed with Read.
+//
+//ReadNetstring returns io.EOF only if the stream ends before the netstring
+//begins.
+//If it ends within the netstring, ReadNetstring returns io.ErrUnexpectedEOF.
+//An invalid length, or a payload not followed by a comma,
+//results in an error wrapping ErrMalformedNetstring.
+func (n *NetstringReader) ReadNetstring() ([]byte, error) {
+	return readNetstring(n.Reader, n.MaxLength)
+}
+
+func readNetstring(src *Reader, maxLength int) ([]byte, error) {
+	limit := int64(math.MaxInt64)
+	if maxLength > 0 {
+		limit = int64(maxLength)
+	}
+
+	br := &byteReader{r: src}
+	var size int64
+	for digits := 0; ; digits++ {
+		b, err := br.ReadByte()
+		if err == io.EOF && digits > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if b == ':' && digits > 0 {
+			break
+		}
+		if b < '0' || b > '9' {
+			return nil, fmt.Errorf("%w: bad length byte %q", ErrMalformedNetstring, b)
+		}
+		if digits == 1 && size == 0 {
+			return nil, fmt.Errorf("%w: leading zero in length", ErrMalformedNetstring)
+		}
+		d := int64(b - '0')
+		if size > limit/10 || size*10 > limit-d {
+			return nil, fmt.Errorf("%w: netstring longer than %d bytes", ErrFrameTooLarge, limit)
+		}
+		size = size*10 + d
+	}
+
+	//grow the payload as it arrives rather than trusting size
+	payload, err := io.ReadAll(io.LimitReader(src, size))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(payload)) < size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b, err := br.ReadByte()
+	if err != nil {
+		return nil, noEOF(err)
+	}
+	if b != ',' {
+		return nil, fmt.Errorf("%w: payload followed by %q, not ','", ErrMalformedNetstring, b)
+	}
+	return payload, nil
+}
@@ -0,0 +1,63 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNetstringReader(t *testing.T) {
+	const in = "5:hello,0:,12:hello, world,"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewNetstringReader(wrap(strings.NewReader(in + "rest")))
+		for _, want := range []string{"hello", "", "hello, world"} {
+			got, err := r.ReadNetstring()
+			if err != nil || string(got) != want {
+				t.Fatalf("got %q, %v want %q", got, err, want)
+			}
+		}
+		if rest, err := io.ReadAll(r); err != nil || string(rest) != "rest" {
+			t.Errorf("rest: got %q, %v", rest, err)
+		}
+		if _, err := r.ReadNetstring(); err != io.EOF {
+			t.Errorf("got %v want io.EOF", err)
+		}
+	}
+}
+
+func TestNetstringReaderMalformed(t *testing.T) {
+	cases := []struct {
+		in   string
+		want error
+	}{
+		{"5:hello", io.ErrUnexpectedEOF},
+		{"5:hello;", ErrMalformedNetstring},
+		{"6:hello,", io.ErrUnexpectedEOF},
+		{"4:hello,", ErrMalformedNetstring},
+		{":hello,", ErrMalformedNetstring},
+		{"05:hello,", ErrMalformedNetstring},
+		{"-5:hello,", ErrMalformedNetstring},
+		{"5", io.ErrUnexpectedEOF},
+		{"2000000:", ErrFrameTooLarge},
+		{"99999999999999999999999:", ErrFrameTooLarge},
+	}
+	for _, c := range cases {
+		_, err := NewNetstringReader(strings.NewReader(c.in)).ReadNetstring()
+		if !errors.Is(err, c.want) {
+			t.Errorf("%q: got %v want %v", c.in, err, c.want)
+		}
+	}
+
+	r := NewNetstringReader(strings.NewReader("5:hello,"))
+	r.MaxLength = 4
+	if _, err := r.ReadNetstring(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("got %v want ErrFrameTooLarge", err)
+	}
+	r = NewNetstringReader(strings.NewReader("99999999999999999999:"))
+	r.MaxLength = 0
+	if _, err := r.ReadNetstring(); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("got %v want ErrFrameTooLarge", err)
+	}
+}
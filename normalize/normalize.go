@@ -0,0 +1,26 @@
+//Package normalize provides a simple.Reader that applies Unicode
+//normalization.
+//
+//It is separate from package simple to contain the dependency
+//on golang.org/x/text.
+package normalize
+
+import (
+	"io"
+
+	"github.com/jimmyfrasche/simple"
+	"golang.org/x/text/unicode/norm"
+)
+
+//NewNormalizeReader returns a Reader of the UTF-8 text of r
+//in the normalization form given, such as norm.NFC.
+//
+//Sequences of runes that normalize together may span any number of reads
+//of r: the end of each read is held back until it is known to be complete.
+//Invalid UTF-8 is passed through unchanged.
+func NewNormalizeReader(r io.Reader, form norm.Form) *simple.Reader {
+	if r == nil {
+		panic("cannot wrap nil io.Reader")
+	}
+	return simple.NewReader(form.Reader(r))
+}
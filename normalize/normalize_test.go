@@ -0,0 +1,39 @@
+package normalize
+
+import (
+	"io"
+	"testing"
+
+	"github.com/jimmyfrasche/simple"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNewNormalizeReader(t *testing.T) {
+	const (
+		composed   = "caf\u00e9 \u1e69 \ufb01"
+		decomposed = "cafe\u0301 s\u0323\u0307 \ufb01"
+		//the combining marks out of canonical order
+		unordered = "cafe\u0301 s\u0307\u0323 \ufb01"
+	)
+	tests := []struct {
+		form norm.Form
+		want string
+	}{
+		{norm.NFC, composed},
+		{norm.NFD, decomposed},
+		{norm.NFKC, "caf\u00e9 \u1e69 fi"},
+		{norm.NFKD, "cafe\u0301 s\u0323\u0307 fi"},
+	}
+	for _, tt := range tests {
+		for _, in := range []string{composed, decomposed, unordered} {
+			//split between every pair of bytes, including within runes
+			for i := 0; i <= len(in); i++ {
+				src := simple.NewScriptedReader([]byte(in), []int{i, 1})
+				got, err := io.ReadAll(NewNormalizeReader(src, tt.form))
+				if err != nil || string(got) != tt.want {
+					t.Errorf("%v of %+q split at %d: got %+q, %v want %+q", tt.form, in, i, got, err, tt.want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package simple
+
+import "io"
+
+//maxNumberToken is the longest number NewNumberNormalizeReader rewrites.
+const maxNumberToken = 256
+
+//NewNumberNormalizeReader returns a Reader that rewrites numbers in r
+//using decimalSep and groupSep, as in "1.234,56" with a decimalSep of ','
+//and a groupSep of '.', to the canonical form, as in "1234.56".
+//
+//A number is a run of digits that may contain separators between its
+//digits, each separator preceded and followed by a digit.
+//It is only rewritten if it is entirely in the form given:
+//digits, then optionally decimalSep and more digits,
+//where the digits before any decimalSep may be split into groups of three
+//by groupSep, after a first group of one to three.
+//Anything else, such as "1.234.5", a number in a different form,
+//or a number longer than 256 bytes, is left as is,
+//as is all text outside numbers.
+//
+//Numbers may span any number of reads of r.
+//
+//NewNumberNormalizeReader panics if decimalSep and groupSep are the same,
+//or either is a digit.
+func NewNumberNormalizeReader(r io.Reader, decimalSep, groupSep byte) *Reader {
+	switch {
+	case decimalSep == groupSep:
+		panic("decimal and group separators must differ")
+	case isDigit(decimalSep) || isDigit(groupSep):
+		panic("separators cannot be digits")
+	}
+	return newTransformReader(r, &numberTransform{decimal: decimalSep, group: groupSep})
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+type numberTransform struct {
+	decimal, group byte
+	tok            []byte //the number so far, including any trailing separator
+	long           bool   //whether the current number was too long to rewrite
+}
+
+func (t *numberTransform) isSep(b byte) bool {
+	return b == t.decimal || b == t.group
+}
+
+func (t *numberTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch {
+		case t.long:
+			//pass the rest of the number through
+			out = append(out, b)
+			t.long = isDigit(b) || t.isSep(b)
+		case isDigit(b):
+			t.tok = append(t.tok, b)
+			if len(t.tok) > maxNumberToken {
+				out = append(out, t.tok...)
+				t.tok, t.long = t.tok[:0], true
+			}
+		case len(t.tok) > 0 && t.isSep(b) && !t.isSep(t.tok[len(t.tok)-1]):
+			//a separator only belongs to the number if a digit follows
+			t.tok = append(t.tok, b)
+		default:
+			out = append(t.end(out), b)
+		}
+	}
+	return out, nil
+}
+
+func (t *numberTransform) flush(out []byte) ([]byte, error) {
+	return t.end(out), nil
+}
+
+//end appends the number that has just ended, rewritten if possible,
+//and any separator after it.
+func (t *numberTransform) end(out []byte) []byte {
+	tok := t.tok
+	var sep []byte
+	if n := len(tok); n > 0 && t.isSep(tok[n-1]) {
+		tok, sep = tok[:n-1], tok[n-1:]
+	}
+	if canon, ok := t.canonical(out, tok); ok {
+		out = canon
+	} else {
+		out = append(out, tok...)
+	}
+	out = append(out, sep...)
+	t.tok = t.tok[:0]
+	return out
+}
+
+//canonical appends tok in canonical form,
+//or reports false if it is not in the expected form.
+//tok begins and ends with a digit and has no adjacent separators.
+func (t *numberTransform) canonical(out, tok []byte) ([]byte, bool) {
+	start := len(out)
+	digits := 0 //in the current group
+	grouped, decimal := false, false
+	for _, b := range tok {
+		switch b {
+		case t.group:
+			if decimal || !grouped && digits > 3 || grouped && digits != 3 {
+				return out[:start], false
+			}
+			grouped, digits = true, 0
+		case t.decimal:
+			if decimal || grouped && digits != 3 {
+				return out[:start], false
+			}
+			decimal = true
+			out = append(out, '.')
+		default:
+			digits++
+			out = append(out, b)
+		}
+	}
+	if grouped && !decimal && digits != 3 {
+		return out[:start], false
+	}
+	return out, true
+}
@@ -0,0 +1,61 @@
+package simple
+
+import (
+	"io"
+	"testing"
+)
+
+func TestNumberNormalizeReader(t *testing.T) {
+	const in = "EU 1.234,56 and 1.234.567,8 and 0,5; US 1,234.56 and 1,234,567.8; " +
+		"both 12 and 1234,5. Not v1.2.3, 12.34.56, 1234.567,8, or 1,23.4."
+	tests := map[string]struct {
+		decimal, group byte
+		want           string
+	}{
+		"EU": {',', '.', "EU 1234.56 and 1234567.8 and 0.5; US 1,234.56 and 1,234,567.8; " +
+			"both 12 and 1234.5. Not v1.2.3, 12.34.56, 1234.567,8, or 1,23.4."},
+		"US": {'.', ',', "EU 1.234,56 and 1.234.567,8 and 0,5; US 1234.56 and 1234567.8; " +
+			"both 12 and 1234,5. Not v1.2.3, 12.34.56, 1234.567,8, or 1,23.4."},
+	}
+	for name, tt := range tests {
+		for i := 0; i <= len(in); i++ {
+			r := NewNumberNormalizeReader(NewScriptedReader([]byte(in), []int{i, 1, 3}), tt.decimal, tt.group)
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("%s split at %d: got %q, %v", name, i, got, err)
+			}
+		}
+	}
+}
+
+func TestNumberNormalizeReaderEdges(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"1.000", "1000"},
+		{"1.000,", "1000,"},
+		{"1.00", "1.00"},
+		{",5", ",5"},
+		{"12..3", "12..3"},
+		{"1.000.000", "1000000"},
+		{"1234.567", "1234.567"},
+	}
+	for _, tt := range tests {
+		got, _ := io.ReadAll(NewNumberNormalizeReader(NewScriptedReader([]byte(tt.in), []int{1}), ',', '.'))
+		if string(got) != tt.want {
+			t.Errorf("%q: got %q want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNumberNormalizeReaderLong(t *testing.T) {
+	long := "1.000"
+	for len(long) <= maxNumberToken {
+		long += ".000"
+	}
+	got, _ := io.ReadAll(NewNumberNormalizeReader(NewScriptedReader([]byte(long+" 1.000"), []int{7}), ',', '.'))
+	if string(got) != long+" 1000" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+package simple
+
+//Option configures a Reader created by NewReader.
+type Option func(*Reader)
+
+//WithErrorMapper applies fn to every error before the Reader returns it
+//from Read or Err, including errors that were stored from a previous read.
+//
+//This allows errors from a particular io.Reader to be wrapped, translated,
+//or annotated.
+//For example, fn may return io.EOF to treat a reader specific
+//sentinel error as the end of the stream.
+//
+//fn is also called with io.EOF.
+//It must return io.EOF unchanged to preserve the end of stream,
+//unless it means to change how the end of the stream is reported.
+//
+//If fn returns nil, the error is discarded
+//and Read proceeds to a fresh read of the wrapped io.Reader.
+//If the wrapped io.Reader keeps returning errors that fn discards,
+//without returning data, Read eventually gives up with io.ErrNoProgress.
+//
+//If WithErrorMapper is given more than once, only the last applies.
+func WithErrorMapper(fn func(error) error) Option {
+	return func(r *Reader) {
+		r.mapErr = fn
+	}
+}
+
+//Metrics receives observations from a Reader.
+//
+//It allows any metrics collector to be plugged in to a Reader.
+type Metrics interface {
+	//BytesRead is called with n > 0 each time Read returns n bytes.
+	BytesRead(n int)
+	//ReadError is called each time Read or Err returns an error,
+	//other than io.EOF, with the error as it is returned to the caller.
+	ReadError(err error)
+}
+
+//NopMetrics is a Metrics that does nothing.
+//
+//It may be embedded to implement only part of Metrics.
+type NopMetrics struct{}
+
+//BytesRead does nothing.
+func (NopMetrics) BytesRead(int) {}
+
+//ReadError does nothing.
+func (NopMetrics) ReadError(error) {}
+
+//WithMetrics reports each Read to m.
+//
+//When data is returned with an error by the wrapped io.Reader,
+//the bytes are reported when they are returned
+//and the error is reported when it is returned by a later Read.
+//
+//A nil m is equivalent to NopMetrics.
+func WithMetrics(m Metrics) Option {
+	if m == nil {
+		m = NopMetrics{}
+	}
+	return func(r *Reader) {
+		r.metrics = m
+	}
+}
+
+//WithEOFError makes Read and Err return err in place of io.EOF,
+//for callers that must treat the end of the stream as a failure.
+//
+//The substitution is made after any WithErrorMapper function is applied,
+//so it also replaces errors that function maps to io.EOF.
+//Once the stream has ended, every later Read returns err,
+//just as it would otherwise return io.EOF.
+//The substituted err is not reported to Metrics.
+func WithEOFError(err error) Option {
+	return func(r *Reader) {
+		r.eofErr = err
+	}
+}
+
+//WithErrorAggregation keeps each error that a WithErrorMapper function
+//discards, by returning nil, rather than forgetting it.
+//
+//When Read or Err next returns an error,
+//it returns errors.Join of the discarded errors, in the order they occurred,
+//followed by that error, so a session that survived a flaky io.Reader
+//reports everything that went wrong along the way.
+//The discarded errors are reported once, so later calls return just the error.
+//
+//Note that a joined io.EOF is not == io.EOF, though errors.Is still matches it,
+//so functions like io.ReadAll return it as an error.
+//If no errors were discarded, io.EOF is returned as usual.
+//
+//Without WithErrorMapper, no errors are discarded
+//and WithErrorAggregation has no effect.
+func WithErrorAggregation() Option {
+	return func(r *Reader) {
+		r.aggregate = true
+	}
+}
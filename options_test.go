@@ -0,0 +1,246 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//dataErr is an io.Reader that returns err along with the last of its data,
+//then io.EOF thereafter.
+type dataErr struct {
+	data string
+	err  error
+}
+
+func (d *dataErr) Read(p []byte) (int, error) {
+	n := copy(p, d.data)
+	d.data = d.data[n:]
+	if len(d.data) > 0 {
+		return n, nil
+	}
+	err := d.err
+	d.err = io.EOF
+	return n, err
+}
+
+var errTruncated = errors.New("truncated")
+
+func TestWithErrorMapperToEOF(t *testing.T) {
+	toEOF := WithErrorMapper(func(err error) error {
+		if err == errTruncated {
+			return io.EOF
+		}
+		return err
+	})
+
+	readers := map[string]io.Reader{
+		"deferred":  &dataErr{"abc", errTruncated},
+		"immediate": io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errTruncated)),
+	}
+	for name, ur := range readers {
+		got, err := io.ReadAll(NewReader(ur, toEOF))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if string(got) != "abc" {
+			t.Errorf("%s: got %q", name, got)
+		}
+	}
+}
+
+func TestWithErrorMapperAnnotate(t *testing.T) {
+	r := NewReader(&dataErr{"abc", errTruncated}, WithErrorMapper(func(err error) error {
+		return fmt.Errorf("source a: %w", err)
+	}))
+
+	p, err := Read(r, make([]byte, 10))
+	if string(p) != "abc" || err != nil {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	if err := r.Err(); !errors.Is(err, errTruncated) || err.Error() != "source a: truncated" {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestWithErrorMapperEOF(t *testing.T) {
+	var seen []error
+	keep := NewReader(strings.NewReader(""), WithErrorMapper(func(err error) error {
+		seen = append(seen, err)
+		return err
+	}))
+	if _, err := keep.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("got %v want io.EOF", err)
+	}
+	if len(seen) != 1 || seen[0] != io.EOF {
+		t.Errorf("mapper saw %v, want [EOF]", seen)
+	}
+
+	change := NewReader(strings.NewReader(""), WithErrorMapper(func(err error) error {
+		return io.ErrUnexpectedEOF
+	}))
+	if _, err := change.Read(make([]byte, 1)); err != io.ErrUnexpectedEOF {
+		t.Errorf("got %v want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestWithErrorMapperNil(t *testing.T) {
+	//the first read returns data and a transient error,
+	//which is swallowed so the next read continues the stream
+	ur := io.MultiReader(&dataErr{"ab", errTruncated}, strings.NewReader("cd"))
+	r := NewReader(ur, WithErrorMapper(func(err error) error {
+		if err == errTruncated {
+			return nil
+		}
+		return err
+	}))
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "abcd" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestWithErrorMapperNilRepeated(t *testing.T) {
+	calls := 0
+	r := NewReader(iotest.ErrReader(errTruncated), WithErrorMapper(func(err error) error {
+		calls++
+		return nil
+	}))
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.ErrNoProgress {
+		t.Errorf("got %d, %v want 0, io.ErrNoProgress", n, err)
+	}
+	if calls != maxNoProgress {
+		t.Errorf("mapper called %d times, want %d", calls, maxNoProgress)
+	}
+}
+
+type fakeMetrics struct {
+	reads []int
+	errs  []error
+}
+
+func (f *fakeMetrics) BytesRead(n int)     { f.reads = append(f.reads, n) }
+func (f *fakeMetrics) ReadError(err error) { f.errs = append(f.errs, err) }
+
+func TestWithMetrics(t *testing.T) {
+	m := &fakeMetrics{}
+	r := NewReader(&dataErr{"Hello, World!", errTruncated}, WithMetrics(m))
+
+	p := make([]byte, 10)
+	for {
+		if _, err := r.Read(p); err != nil {
+			break
+		}
+	}
+
+	if fmt.Sprint(m.reads) != "[10 3]" {
+		t.Errorf("got reads %v want [10 3]", m.reads)
+	}
+	if len(m.errs) != 1 || m.errs[0] != errTruncated {
+		t.Errorf("got errors %v want [truncated]", m.errs)
+	}
+
+	//io.EOF is not reported
+	if _, err := r.Read(p); err != io.EOF {
+		t.Fatalf("got %v want io.EOF", err)
+	}
+	if len(m.errs) != 1 {
+		t.Errorf("got errors %v", m.errs)
+	}
+}
+
+func TestWithMetricsMapped(t *testing.T) {
+	m := &fakeMetrics{}
+	r := NewReader(&dataErr{"abc", errTruncated},
+		WithMetrics(m),
+		WithErrorMapper(func(err error) error {
+			return fmt.Errorf("mapped: %w", err)
+		}),
+	)
+	if _, err := Read(r, make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Err()
+	if len(m.errs) != 1 || m.errs[0] != err {
+		t.Errorf("got errors %v want [%v]", m.errs, err)
+	}
+}
+
+func TestWithEOFError(t *testing.T) {
+	errEnd := errors.New("unexpected end of config")
+	m := &fakeMetrics{}
+	r := NewReader(&dataErr{"abc", errTruncated},
+		WithMetrics(m),
+		WithErrorMapper(func(err error) error {
+			if err == errTruncated {
+				return io.EOF
+			}
+			return err
+		}),
+		WithEOFError(errEnd),
+	)
+
+	p, err := Read(r, make([]byte, 10))
+	if string(p) != "abc" || err != nil {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err := r.Read(make([]byte, 10))
+		if err != errEnd {
+			t.Errorf("read %d: got %v want %v", i, err, errEnd)
+		}
+		if errors.Is(err, io.EOF) {
+			t.Errorf("read %d: %v matches io.EOF", i, err)
+		}
+	}
+	if len(m.errs) != 0 {
+		t.Errorf("reported %v", m.errs)
+	}
+}
+
+func TestWithErrorAggregation(t *testing.T) {
+	errs := []error{errors.New("one"), errors.New("two"), errors.New("three")}
+	var parts []io.Reader
+	for i, e := range errs {
+		parts = append(parts, &dataErr{string(rune('a' + i)), e})
+	}
+	parts = append(parts, strings.NewReader("d"))
+
+	transient := func(err error) error {
+		for _, e := range errs {
+			if err == e {
+				return nil
+			}
+		}
+		return err
+	}
+	r := NewReader(io.MultiReader(parts...), WithErrorMapper(transient), WithErrorAggregation())
+
+	got, err := io.ReadAll(r)
+	if string(got) != "abcd" {
+		t.Errorf("got %q want %q", got, "abcd")
+	}
+	for _, e := range append(errs, io.EOF) {
+		if !errors.Is(err, e) {
+			t.Errorf("%v does not report %v", err, e)
+		}
+	}
+	if want := "one\ntwo\nthree\nEOF"; err == nil || err.Error() != want {
+		t.Errorf("got %v want %q", err, want)
+	}
+
+	//reported only once
+	if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("got %v want io.EOF", err)
+	}
+}
+
+func TestWithErrorAggregationClean(t *testing.T) {
+	r := NewReader(strings.NewReader("abc"), WithErrorMapper(func(err error) error { return err }), WithErrorAggregation())
+	if got, err := io.ReadAll(r); err != nil || string(got) != "abc" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,54 @@
+package simple
+
+import (
+	"errors"
+	"io"
+)
+
+//peekWindow is the least PeekAt reads from the wrapped io.ReaderAt at once.
+const peekWindow = 4096
+
+//errNegativeOffset is returned by PeekAt for an offset before the start.
+var errNegativeOffset = errors.New("simple: negative offset")
+
+//PeekAt returns the n bytes at offset off of the wrapped io.ReaderAt
+//without moving the position of the next Read,
+//or an error matching errors.ErrUnsupported if it is not an io.ReaderAt.
+//
+//PeekAt reads and caches at least 4KiB at a time,
+//so nearby peeks are served without calling ReadAt again.
+//The cache assumes the underlying data does not change.
+//The returned slice is only valid until the next call to PeekAt
+//and must not be modified.
+//
+//If fewer than n bytes are available at off,
+//PeekAt returns those that are along with the error, such as io.EOF,
+//that cut the read short.
+//As with ReadAt, PeekAt does not return or clear stored errors.
+func (r *Reader) PeekAt(off int64, n int) ([]byte, error) {
+	if off < 0 {
+		return nil, errNegativeOffset
+	}
+	if n < 0 {
+		panic("negative peek length")
+	}
+
+	if start := off - r.peekOff; start >= 0 && start+int64(n) <= int64(len(r.peek)) {
+		return r.peek[start : start+int64(n)], nil
+	}
+
+	size := max(n, peekWindow)
+	if cap(r.peek) < size {
+		r.freeBuf(r.peek[:cap(r.peek)])
+		r.peek = r.allocBuf(size)
+	}
+	got, err := r.ReadAt(r.peek[:size], off)
+	r.peek, r.peekOff = r.peek[:got], off
+	if got < n {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return r.peek, err
+	}
+	return r.peek[:n], nil
+}
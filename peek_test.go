@@ -0,0 +1,74 @@
+package simple
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+//countingReaderAt counts calls to ReadAt.
+type countingReaderAt struct {
+	*bytes.Reader
+	calls int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.calls++
+	return c.Reader.ReadAt(p, off)
+}
+
+func TestPeekAt(t *testing.T) {
+	//a body followed by a trailer giving the offset of its last section
+	body := strings.Repeat("x", 10000) + "section"
+	var trailer [8]byte
+	binary.BigEndian.PutUint64(trailer[:], 10000)
+	src := &countingReaderAt{Reader: bytes.NewReader([]byte(body + string(trailer[:])))}
+	r := NewReader(src)
+
+	end := int64(len(body) + 8)
+	t8, err := r.PeekAt(end-8, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec, err := r.PeekAt(int64(binary.BigEndian.Uint64(t8)), 7)
+	if err != nil || string(sec) != "section" {
+		t.Fatalf("got %q, %v", sec, err)
+	}
+	if src.calls != 2 {
+		t.Errorf("ReadAt called %d times, want 2", src.calls)
+	}
+
+	//nearby peeks use the cache
+	if p, err := r.PeekAt(10003, 4); err != nil || string(p) != "tion" {
+		t.Errorf("got %q, %v", p, err)
+	}
+	if src.calls != 2 {
+		t.Errorf("ReadAt called %d times, want 2", src.calls)
+	}
+
+	//the sequential position is unmoved
+	p, err := Read(r, make([]byte, 4))
+	if err != nil || string(p) != "xxxx" {
+		t.Errorf("got %q, %v", p, err)
+	}
+}
+
+func TestPeekAtShort(t *testing.T) {
+	r := NewReader(strings.NewReader("abcdef"))
+	if p, err := r.PeekAt(4, 4); err != io.EOF || string(p) != "ef" {
+		t.Errorf("got %q, %v", p, err)
+	}
+	if _, err := r.PeekAt(-1, 1); err == nil {
+		t.Error("negative offset accepted")
+	}
+}
+
+func TestPeekAtUnsupported(t *testing.T) {
+	r := NewReader(io.MultiReader(strings.NewReader("abc")))
+	if _, err := r.PeekAt(0, 1); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("got %v want errors.ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,71 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrMalformedPercentEncoding is returned, wrapped with more detail,
+//by a Reader from NewPercentDecodeReader for an invalid escape.
+var ErrMalformedPercentEncoding = errors.New("simple: malformed percent-encoding")
+
+//NewPercentDecodeReader returns a Reader that decodes each %XX escape
+//read from r to the byte with hex value XX.
+//All other bytes are passed through.
+//
+//Escapes may span any number of reads of r.
+//A % not followed by two hex digits, including at the end of the stream,
+//results in an error wrapping ErrMalformedPercentEncoding
+//that gives its offset, returned after the data that preceded it.
+func NewPercentDecodeReader(r io.Reader) *Reader {
+	return newTransformReader(r, &percentTransform{})
+}
+
+//NewFormDecodeReader is like NewPercentDecodeReader
+//except that it also decodes + to a space,
+//as in application/x-www-form-urlencoded bodies.
+func NewFormDecodeReader(r io.Reader) *Reader {
+	return newTransformReader(r, &percentTransform{plus: true})
+}
+
+type percentTransform struct {
+	plus bool
+	held []byte //the escape read so far, starting with %
+	off  int64  //offset in the input of the start of in
+}
+
+func (t *percentTransform) push(out, in []byte) ([]byte, error) {
+	for i, b := range in {
+		switch {
+		case len(t.held) > 0:
+			if !IsHexDigit(b) {
+				return out, t.malformed(t.off + int64(i))
+			}
+			if t.held = append(t.held, b); len(t.held) == 3 {
+				out = append(out, unhex(t.held[1])<<4|unhex(t.held[2]))
+				t.held = t.held[:0]
+			}
+		case b == '%':
+			t.held = append(t.held, b)
+		case b == '+' && t.plus:
+			out = append(out, ' ')
+		default:
+			out = append(out, b)
+		}
+	}
+	t.off += int64(len(in))
+	return out, nil
+}
+
+func (t *percentTransform) flush(out []byte) ([]byte, error) {
+	if len(t.held) > 0 {
+		return out, t.malformed(t.off)
+	}
+	return out, nil
+}
+
+//malformed reports the held escape, which is cut short at offset end.
+func (t *percentTransform) malformed(end int64) error {
+	return fmt.Errorf("%w: bad escape at offset %d", ErrMalformedPercentEncoding, end-int64(len(t.held)))
+}
@@ -0,0 +1,52 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPercentDecodeReader(t *testing.T) {
+	const in = "a%20b%2Bc+d%e2%82%ac%25"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewPercentDecodeReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != "a b+c+d€%" {
+			t.Errorf("got %q, %v", got, err)
+		}
+		got, err = io.ReadAll(NewFormDecodeReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != "a b+c d€%" {
+			t.Errorf("form: got %q, %v", got, err)
+		}
+	}
+
+	//every split of an escape across two reads
+	for i := 0; i <= len(in); i++ {
+		r := NewScriptedReader([]byte(in), []int{i, len(in)})
+		got, err := io.ReadAll(NewPercentDecodeReader(r))
+		if err != nil || string(got) != "a b+c+d€%" {
+			t.Errorf("split at %d: got %q, %v", i, got, err)
+		}
+	}
+}
+
+func TestPercentDecodeReaderMalformed(t *testing.T) {
+	cases := []struct{ in, before, at string }{
+		{"ab%zz", "ab", "offset 2"},
+		{"ab%4z", "ab", "offset 2"},
+		{"abc%4", "abc", "offset 3"},
+		{"abcd%", "abcd", "offset 4"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader} {
+			got, err := io.ReadAll(NewPercentDecodeReader(wrap(strings.NewReader(c.in))))
+			if !errors.Is(err, ErrMalformedPercentEncoding) || !strings.Contains(err.Error(), c.at) {
+				t.Errorf("%q: got %v want error at %s", c.in, err, c.at)
+			}
+			if string(got) != c.before {
+				t.Errorf("%q: got %q want %q", c.in, got, c.before)
+			}
+		}
+	}
+}
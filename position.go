@@ -0,0 +1,48 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//PositionReader is a Reader that tracks the line and column
+//of the data it has delivered.
+type PositionReader struct {
+	*Reader
+	p *positionReader
+}
+
+//NewPositionReader wraps r in a PositionReader.
+func NewPositionReader(r io.Reader) *PositionReader {
+	must(r)
+	p := &positionReader{r: r, line: 1, col: 1}
+	return &PositionReader{
+		Reader: NewReader(p),
+		p:      p,
+	}
+}
+
+//Position returns the 1-based line and column of the next byte
+//to be delivered.
+//
+//Lines are separated by \n and columns count bytes, not runes.
+func (p *PositionReader) Position() (line, col int) {
+	return p.p.line, p.p.col
+}
+
+type positionReader struct {
+	r         io.Reader
+	line, col int
+}
+
+func (p *positionReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	b = b[:n]
+	if nl := bytes.Count(b, []byte{'\n'}); nl > 0 {
+		p.line += nl
+		p.col = n - bytes.LastIndexByte(b, '\n')
+	} else {
+		p.col += n
+	}
+	return n, err
+}
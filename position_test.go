@@ -0,0 +1,54 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPositionReader(t *testing.T) {
+	const in = "ab\ncde\n\nf\n"
+	//position before each byte of in, and at the end
+	want := [][2]int{
+		{1, 1}, {1, 2}, {1, 3},
+		{2, 1}, {2, 2}, {2, 3}, {2, 4},
+		{3, 1},
+		{4, 1}, {4, 2},
+		{5, 1},
+	}
+
+	r := NewPositionReader(iotest.OneByteReader(strings.NewReader(in)))
+	p := make([]byte, 1)
+	for i := 0; ; i++ {
+		line, col := r.Position()
+		if line != want[i][0] || col != want[i][1] {
+			t.Errorf("offset %d: got %d:%d want %d:%d", i, line, col, want[i][0], want[i][1])
+		}
+		if _, err := r.Read(p); err != nil {
+			break
+		}
+	}
+}
+
+func TestPositionReaderChunks(t *testing.T) {
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.HalfReader, iotest.DataErrReader} {
+		r := NewPositionReader(wrap(strings.NewReader("first\nsecond\nthird")))
+		if _, err := io.ReadAll(r); err != nil {
+			t.Fatal(err)
+		}
+		if line, col := r.Position(); line != 3 || col != 6 {
+			t.Errorf("got %d:%d want 3:6", line, col)
+		}
+	}
+
+	//data delivered with a deferred error is counted once it is delivered
+	b := NewBasic("a\nbc")
+	r := NewPositionReader(&b)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if line, col := r.Position(); line != 2 || col != 3 {
+		t.Errorf("got %d:%d want 2:3", line, col)
+	}
+}
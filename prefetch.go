@@ -0,0 +1,179 @@
+package simple
+
+import (
+	"io"
+	"runtime"
+	"sync"
+)
+
+//PrefetchReader is a Reader that reads ahead of its caller
+//in a background goroutine.
+type PrefetchReader struct {
+	*Reader
+	p *prefetchReader
+}
+
+//NewPrefetchReader wraps r in a PrefetchReader that reads from r
+//into buffers of bufSize bytes in a background goroutine,
+//so that Read is served from memory while slow reads of r proceed.
+//At most two buffers are read ahead.
+//
+//The goroutine is started by the first Read.
+//It stops once r returns an error, including io.EOF,
+//so r is never read after it ends.
+//Errors are returned in order, after the data read before them.
+//
+//The goroutine is also stopped by Close,
+//or once the PrefetchReader is no longer reachable,
+//so an abandoned PrefetchReader does not leak it,
+//although a read of r already in progress must still finish.
+//
+//NewPrefetchReader panics if bufSize < 1.
+func NewPrefetchReader(r io.Reader, bufSize int) *PrefetchReader {
+	must(r)
+	if bufSize < 1 {
+		panic("buffer size must be positive")
+	}
+	p := &prefetchReader{
+		src: &prefetchSource{
+			r:      r,
+			size:   bufSize,
+			chunks: make(chan prefetchChunk, 1),
+			free:   make(chan []byte, 2),
+			closed: make(chan struct{}),
+		},
+	}
+	pr := &PrefetchReader{
+		Reader: NewReader(p),
+		p:      p,
+	}
+	//the goroutine only refers to src, so the *Reader can become
+	//unreachable while it runs
+	runtime.SetFinalizer(pr.Reader, func(*Reader) { p.src.stop() })
+	return pr
+}
+
+//Close stops the background goroutine and the reader.
+//Any Read waiting for data returns ErrClosed, as do all later Reads.
+//If the wrapped io.Reader is an io.Closer, it is closed.
+//
+//Close may be called concurrently with Read.
+func (p *PrefetchReader) Close() error {
+	return p.p.src.close()
+}
+
+type prefetchChunk struct {
+	data []byte
+	err  error
+}
+
+//prefetchSource is the part of a prefetchReader shared with its goroutine.
+type prefetchSource struct {
+	r      io.Reader
+	size   int
+	chunks chan prefetchChunk
+	free   chan []byte //buffers returned by the reader for reuse
+
+	stopOnce  sync.Once
+	closed    chan struct{}
+	closeOnce sync.Once
+	err       error //from closing r
+}
+
+func (s *prefetchSource) run() {
+	defer close(s.chunks)
+	var buf []byte
+	for stalls := 0; ; {
+		if buf == nil {
+			select {
+			case buf = <-s.free:
+			default:
+				buf = make([]byte, s.size)
+			}
+		}
+
+		n, err := s.r.Read(buf)
+		if n == 0 && err == nil {
+			if stalls++; stalls < maxNoProgress {
+				continue
+			}
+			err = io.ErrNoProgress
+		}
+		stalls = 0
+
+		select {
+		case s.chunks <- prefetchChunk{buf[:n], err}:
+			buf = nil
+		case <-s.closed:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+//stop stops the goroutine.
+func (s *prefetchSource) stop() {
+	s.stopOnce.Do(func() { close(s.closed) })
+}
+
+//close stops the goroutine and closes r.
+func (s *prefetchSource) close() error {
+	s.stop()
+	s.closeOnce.Do(func() {
+		if c, ok := s.r.(io.Closer); ok {
+			s.err = c.Close()
+		}
+	})
+	return s.err
+}
+
+type prefetchReader struct {
+	src     *prefetchSource
+	started bool
+	cur     prefetchChunk
+	buf     []byte //backing the current chunk
+}
+
+func (p *prefetchReader) Read(b []byte) (int, error) {
+	if !p.started {
+		p.started = true
+		go p.src.run()
+	}
+
+	for {
+		select {
+		case <-p.src.closed:
+			return 0, ErrClosed
+		default:
+		}
+
+		if len(p.cur.data) > 0 {
+			n := copy(b, p.cur.data)
+			p.cur.data = p.cur.data[n:]
+			return n, nil
+		}
+		if p.cur.err != nil {
+			return 0, p.cur.err
+		}
+
+		if p.buf != nil {
+			select {
+			case p.src.free <- p.buf[:cap(p.buf)]:
+			default:
+			}
+			p.buf = nil
+		}
+
+		select {
+		case c, ok := <-p.src.chunks:
+			if !ok {
+				return 0, ErrClosed
+			}
+			p.cur, p.buf = c, c.data
+		case <-p.src.closed:
+			return 0, ErrClosed
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"runtime"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestPrefetchReader(t *testing.T) {
+	data := make([]byte, 100<<10)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewPrefetchReader(wrap(bytes.NewReader(data)), 1000)
+		got, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("got %d bytes, %v", len(got), err)
+		}
+		if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+			t.Errorf("got %v want io.EOF", err)
+		}
+		r.Close()
+	}
+}
+
+func TestPrefetchReaderError(t *testing.T) {
+	r := NewPrefetchReader(&dataErr{"abcdef", errTruncated}, 4)
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != errTruncated || string(got) != "abcdef" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+//endOnce fails the test if it is read after returning io.EOF.
+type endOnce struct {
+	t   *testing.T
+	r   io.Reader
+	eof bool
+}
+
+func (e *endOnce) Read(p []byte) (int, error) {
+	if e.eof {
+		e.t.Error("read after io.EOF")
+	}
+	n, err := e.r.Read(p)
+	e.eof = err == io.EOF
+	return n, err
+}
+
+func TestPrefetchReaderStopsAtEOF(t *testing.T) {
+	r := NewPrefetchReader(&endOnce{t: t, r: bytes.NewReader(make([]byte, 10))}, 3)
+	io.ReadAll(r)
+	r.Read(make([]byte, 1))
+	time.Sleep(10 * time.Millisecond)
+	r.Close()
+}
+
+//settle waits for the number of goroutines to fall to want.
+func settle(want int) int {
+	n := runtime.NumGoroutine()
+	for i := 0; i < 100 && n > want; i++ {
+		runtime.GC()
+		time.Sleep(5 * time.Millisecond)
+		n = runtime.NumGoroutine()
+	}
+	return n
+}
+
+func TestPrefetchReaderLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	endless := func() io.Reader { return iotest.HalfReader(zeros{}) }
+
+	r := NewPrefetchReader(endless(), 16)
+	r.Read(make([]byte, 8))
+	r.Close()
+	if n := settle(before); n > before {
+		t.Errorf("%d goroutines after Close, want %d", n, before)
+	}
+
+	//abandoned without Close
+	func() {
+		r := NewPrefetchReader(endless(), 16)
+		r.Read(make([]byte, 8))
+	}()
+	if n := settle(before); n > before {
+		t.Errorf("%d goroutines after abandoning, want %d", n, before)
+	}
+}
+
+func TestPrefetchReaderClose(t *testing.T) {
+	r := NewPrefetchReader(zeros{}, 16)
+	r.Read(make([]byte, 8))
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(make([]byte, 8)); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
+
+//zeros is an endless stream of zeros.
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,37 @@
+package simple
+
+import "io"
+
+//NewPrefixLineReader returns a Reader that delivers r
+//with prefix inserted at the start of every line.
+//
+//Lines end with \n, which may fall at the end of any read of r.
+//The prefix is inserted when the first byte of a line arrives,
+//so an empty stream, or the end of a stream ending in \n,
+//gets no prefix.
+func NewPrefixLineReader(r io.Reader, prefix []byte) *Reader {
+	return newTransformReader(r, &prefixLineTransform{
+		prefix: append([]byte(nil), prefix...),
+		start:  true,
+	})
+}
+
+type prefixLineTransform struct {
+	prefix []byte
+	start  bool //whether the next byte begins a line
+}
+
+func (t *prefixLineTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		if t.start {
+			out = append(out, t.prefix...)
+		}
+		out = append(out, b)
+		t.start = b == '\n'
+	}
+	return out, nil
+}
+
+func (t *prefixLineTransform) flush(out []byte) ([]byte, error) {
+	return out, nil
+}
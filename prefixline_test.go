@@ -0,0 +1,28 @@
+package simple
+
+import (
+	"io"
+	"testing"
+)
+
+func TestPrefixLineReader(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"one", "> one"},
+		{"one\n", "> one\n"},
+		{"one\ntwo\n", "> one\n> two\n"},
+		{"one\n\nthree", "> one\n> \n> three"},
+		{"\n\n", "> \n> \n"},
+	}
+	for _, tt := range tests {
+		for i := 0; i <= len(tt.in); i++ {
+			r := NewPrefixLineReader(NewScriptedReader([]byte(tt.in), []int{i, 1}), []byte("> "))
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("%q split at %d: got %q, %v want %q", tt.in, i, got, err, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package simple
+
+import "io"
+
+//NewPriorityReader returns a Reader that, on each Read, reads from the first
+//of sources that has data available, as reported by the same means
+//as Reader.Available, so that data from earlier sources
+//is always delivered ahead of data from later ones.
+//
+//If no source reports available data, the first source not at EOF is read,
+//which may block until it has data,
+//even if data arrives on a later source in the meantime.
+//A source that cannot report what is available is always treated
+//as having data, so later sources are not read until it reaches EOF.
+//
+//The Reader returns io.EOF once every source has,
+//and stops at the first other error from any source.
+func NewPriorityReader(sources []io.Reader) *Reader {
+	for _, r := range sources {
+		must(r)
+	}
+	return NewReader(&priorityReader{
+		sources: append([]io.Reader(nil), sources...),
+	})
+}
+
+type priorityReader struct {
+	sources []io.Reader //nil once at EOF
+}
+
+func (pr *priorityReader) Read(p []byte) (int, error) {
+	for {
+		src := -1
+		for i, r := range pr.sources {
+			if r == nil {
+				continue
+			}
+			if src < 0 {
+				src = i
+			}
+			if n, err := available(r); n > 0 || err != nil {
+				src = i
+				break
+			}
+		}
+		if src < 0 {
+			return 0, io.EOF
+		}
+
+		n, err := pr.sources[src].Read(p)
+		if err == io.EOF {
+			pr.sources[src] = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
@@ -0,0 +1,53 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPriorityReader(t *testing.T) {
+	control := bytes.NewBufferString("ctl1")
+	data := strings.NewReader("data1data2")
+	r := NewPriorityReader([]io.Reader{control, data})
+
+	p := make([]byte, 2)
+	next := func() string {
+		p, err := Read(r, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(p)
+	}
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, next())
+	}
+
+	//more control data jumps ahead of the rest of the data
+	control.WriteString("ctl2")
+	for i := 0; i < 2; i++ {
+		got = append(got, next())
+	}
+
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, string(rest))
+
+	if s := strings.Join(got, "|"); s != "ct|l1|da|ta|ct|l2|1data2" {
+		t.Errorf("got %s", s)
+	}
+}
+
+func TestPriorityReaderFailover(t *testing.T) {
+	//sources that cannot report availability are read in order
+	hi := NewBasic("high")
+	lo := NewBasic("low")
+	got, err := io.ReadAll(NewPriorityReader([]io.Reader{&hi, &lo}))
+	if err != nil || string(got) != "highlow" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,118 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrMalformedQuotedPrintable is returned, wrapped with more detail,
+//by a Reader from NewQuotedPrintableReader for an invalid escape.
+var ErrMalformedQuotedPrintable = errors.New("simple: malformed quoted-printable")
+
+//NewQuotedPrintableReader returns a Reader that decodes
+//the quoted-printable encoding of RFC 2045 read from r.
+//
+//An = followed by two hex digits is decoded to that byte,
+//and an = at the end of a line is a soft line break and is removed.
+//Escapes may span any number of reads of r.
+//Anything else following an =, including the end of the stream,
+//results in an error wrapping ErrMalformedQuotedPrintable,
+//returned after the data that preceded it.
+//
+//Lowercase hex digits are accepted.
+//Whitespace at the end of lines is passed through as is.
+func NewQuotedPrintableReader(r io.Reader) *Reader {
+	return newTransformReader(r, &qpTransform{})
+}
+
+//NewLenientQuotedPrintableReader is like NewQuotedPrintableReader,
+//except that malformed escapes are passed through literally
+//instead of resulting in an error.
+func NewLenientQuotedPrintableReader(r io.Reader) *Reader {
+	return newTransformReader(r, &qpTransform{lenient: true})
+}
+
+type qpTransform struct {
+	lenient bool
+	held    []byte //the escape read so far, starting with =
+	off     int64  //offset in the input of the current byte
+}
+
+func (q *qpTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		var err error
+		if out, err = q.add(out, b); err != nil {
+			return out, err
+		}
+		q.off++
+	}
+	return out, nil
+}
+
+//add decodes the next byte of input.
+func (q *qpTransform) add(out []byte, b byte) ([]byte, error) {
+	switch len(q.held) {
+	case 0:
+		if b == '=' {
+			q.held = append(q.held, b)
+			return out, nil
+		}
+		return append(out, b), nil
+
+	case 1:
+		switch {
+		case b == '\n':
+			q.held = q.held[:0]
+			return out, nil
+		case b == '\r' || IsHexDigit(b):
+			q.held = append(q.held, b)
+			return out, nil
+		}
+
+	case 2:
+		switch {
+		case q.held[1] == '\r' && b == '\n':
+			q.held = q.held[:0]
+			return out, nil
+		case IsHexDigit(q.held[1]) && IsHexDigit(b):
+			c := unhex(q.held[1])<<4 | unhex(b)
+			q.held = q.held[:0]
+			return append(out, c), nil
+		}
+	}
+
+	if !q.lenient {
+		return out, fmt.Errorf("%w: bad escape %q at offset %d", ErrMalformedQuotedPrintable, append(q.held, b), q.off-int64(len(q.held)))
+	}
+	//pass the = through and start again at the byte after it
+	held := append([]byte(nil), q.held[1:]...)
+	out = append(out, '=')
+	q.held = q.held[:0]
+	for _, h := range append(held, b) {
+		out, _ = q.add(out, h)
+	}
+	return out, nil
+}
+
+func (q *qpTransform) flush(out []byte) ([]byte, error) {
+	if len(q.held) == 0 {
+		return out, nil
+	}
+	if !q.lenient {
+		return out, fmt.Errorf("%w: incomplete escape %q at end", ErrMalformedQuotedPrintable, q.held)
+	}
+	out = append(out, q.held...)
+	q.held = q.held[:0]
+	return out, nil
+}
+
+func unhex(b byte) byte {
+	switch {
+	case b <= '9':
+		return b - '0'
+	case b <= 'F':
+		return b - 'A' + 10
+	}
+	return b - 'a' + 10
+}
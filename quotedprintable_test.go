@@ -0,0 +1,51 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestQuotedPrintableReader(t *testing.T) {
+	const in = "caf=C3=A9 =3d equals=\r\n continued=\nsoft\r\nhard=e2=82=ac"
+	const want = "café = equals continuedsoft\r\nhard€"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := io.ReadAll(NewQuotedPrintableReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != want {
+			t.Errorf("got %q, %v want %q", got, err, want)
+		}
+	}
+
+	//every split of an escape across two reads
+	for i := 0; i <= len(in); i++ {
+		r := NewScriptedReader([]byte(in), []int{i, len(in)})
+		got, err := io.ReadAll(NewQuotedPrintableReader(r))
+		if err != nil || string(got) != want {
+			t.Errorf("split at %d: got %q, %v", i, got, err)
+		}
+	}
+}
+
+func TestQuotedPrintableReaderMalformed(t *testing.T) {
+	cases := []struct{ in, before, lenient string }{
+		{"ab=zz", "ab", "ab=zz"},
+		{"ab=4", "ab", "ab=4"},
+		{"ab=4g", "ab", "ab=4g"},
+		{"ab=\rx", "ab", "ab=\rx"},
+		{"ab==41", "ab", "ab=A"},
+		{"ab=", "ab", "ab="},
+	}
+	for _, c := range cases {
+		got, err := io.ReadAll(NewQuotedPrintableReader(iotest.OneByteReader(strings.NewReader(c.in))))
+		if !errors.Is(err, ErrMalformedQuotedPrintable) || string(got) != c.before {
+			t.Errorf("%q: got %q, %v", c.in, got, err)
+		}
+
+		got, err = io.ReadAll(NewLenientQuotedPrintableReader(strings.NewReader(c.in)))
+		if err != nil || string(got) != c.lenient {
+			t.Errorf("%q lenient: got %q, %v want %q", c.in, got, err, c.lenient)
+		}
+	}
+}
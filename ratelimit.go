@@ -0,0 +1,158 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+//ErrClosed is returned by Read after Close.
+var ErrClosed = errors.New("simple: read on closed reader")
+
+//SharedLimiter is a token bucket limiting the combined rate
+//of every RateLimitedReader using it.
+//
+//It is safe for concurrent use.
+type SharedLimiter struct {
+	mu     sync.Mutex
+	rate   float64 //tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+//NewSharedLimiter returns a SharedLimiter allowing bytesPerSec bytes
+//per second on average, and up to burst bytes at once.
+//The bucket starts full.
+//
+//NewSharedLimiter panics if bytesPerSec or burst is less than 1.
+func NewSharedLimiter(bytesPerSec, burst int) *SharedLimiter {
+	if bytesPerSec < 1 || burst < 1 {
+		panic("limiter rate and burst must be positive")
+	}
+	return &SharedLimiter{
+		rate:   float64(bytesPerSec),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+//take removes between 1 and n tokens from the bucket and returns
+//how many it took, or, if there are none, how long to wait for one.
+func (l *SharedLimiter) take(n int) (int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		return 0, time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	if float64(n) > l.tokens {
+		n = int(l.tokens)
+	}
+	l.tokens -= float64(n)
+	return n, 0
+}
+
+//refund returns n unused tokens.
+func (l *SharedLimiter) refund(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens += float64(n)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+//RateLimitedReader is a Reader whose reads are limited by a SharedLimiter.
+type RateLimitedReader struct {
+	*Reader
+	rl *rateLimitedReader
+}
+
+//NewRateLimitedReader wraps r in a RateLimitedReader drawing from l.
+//
+//To limit a single stream, give it its own SharedLimiter.
+//To limit the total rate of several streams, give them all the same one.
+func NewRateLimitedReader(r io.Reader, l *SharedLimiter) *RateLimitedReader {
+	must(r)
+	if l == nil {
+		panic("nil SharedLimiter")
+	}
+	rl := &rateLimitedReader{
+		r:      r,
+		l:      l,
+		closed: make(chan struct{}),
+	}
+	return &RateLimitedReader{
+		Reader: NewReader(rl),
+		rl:     rl,
+	}
+}
+
+//Close stops the reader.
+//Any Read blocked waiting on the SharedLimiter returns ErrClosed,
+//as do all later Reads.
+//If the wrapped io.Reader is an io.Closer, it is closed.
+//
+//Close may be called concurrently with Read.
+func (r *RateLimitedReader) Close() error {
+	return r.rl.close()
+}
+
+type rateLimitedReader struct {
+	r      io.Reader
+	l      *SharedLimiter
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (rl *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		select {
+		case <-rl.closed:
+			return 0, ErrClosed
+		default:
+		}
+
+		k, wait := rl.l.take(len(p))
+		if k > 0 {
+			n, err := rl.r.Read(p[:k])
+			if n < k {
+				rl.l.refund(k - n)
+			}
+			return n, err
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-rl.closed:
+			t.Stop()
+			return 0, ErrClosed
+		case <-t.C:
+		}
+	}
+}
+
+func (rl *rateLimitedReader) close() error {
+	var err error
+	rl.once.Do(func() {
+		close(rl.closed)
+		if c, ok := rl.r.(io.Closer); ok {
+			err = c.Close()
+		}
+	})
+	return err
+}
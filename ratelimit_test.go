@@ -0,0 +1,66 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharedLimiter(t *testing.T) {
+	const (
+		rate    = 40000
+		burst   = 1000
+		streams = 4
+		size    = 4000
+	)
+	l := NewSharedLimiter(rate, burst)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < streams; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := NewRateLimitedReader(bytes.NewReader(make([]byte, size)), l)
+			n, err := io.Copy(io.Discard, r)
+			if n != size || err != nil {
+				t.Errorf("got %d, %v", n, err)
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	//everything beyond the initial burst must wait for the bucket
+	least := time.Duration(float64(streams*size-burst) / rate * float64(time.Second))
+	if elapsed < least*9/10 {
+		t.Errorf("read %d bytes in %v, faster than the limit allows (%v)", streams*size, elapsed, least)
+	}
+}
+
+func TestRateLimitedReaderClose(t *testing.T) {
+	l := NewSharedLimiter(1, 1)
+	r := NewRateLimitedReader(bytes.NewReader(make([]byte, 10)), l)
+
+	p := make([]byte, 5)
+	if n, err := r.Read(p); n != 1 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		r.Close()
+	}()
+	start := time.Now()
+	if _, err := r.Read(p); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("Close took %v to unblock Read", d)
+	}
+	if _, err := r.Read(p); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
@@ -14,7 +14,11 @@
 //They play well together.
 package simple
 
-import "io"
+import (
+	"errors"
+	"fmt"
+	"io"
+)
 
 //Reader wraps any io.Reader and strengthens the io.Reader contract
 //by never returning an error when n > 0.
@@ -36,16 +40,54 @@ import "io"
 type Reader struct {
 	err error
 	r   io.Reader
+	off int64 //bytes delivered by Read
+
+	//the raw result of the last read of r
+	lastN   int
+	lastErr error
+
+	mapErr   func(error) error
+	eofErr   error
+	metrics  Metrics
+	maxFrame int
+	trimNUL  bool
+
+	keepMagic bool //from WithKeepMagic
+
+	//from WithAllocator
+	alloc func(int) []byte
+	free  func([]byte)
+
+	//errors discarded by mapErr, kept if aggregating
+	aggregate bool
+	discarded []error
+
+	done bool //whether Read or Err has returned the end of the stream
+
+	//the window cached by PeekAt
+	peek    []byte
+	peekOff int64
 }
 
-//NewReader wraps an io.Reader in a simple.Reader.
-func NewReader(r io.Reader) *Reader {
-	if r == nil {
-		panic("cannot wrap nil io.Reader")
+//NewReader wraps an io.Reader in a simple.Reader,
+//configured by any opts.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	must(r)
+
+	sr := &Reader{
+		r:       r,
+		metrics: NopMetrics{},
 	}
+	for _, opt := range opts {
+		opt(sr)
+	}
+	return sr
+}
 
-	return &Reader{
-		r: r,
+//must panics if r is nil.
+func must(r io.Reader) {
+	if r == nil {
+		panic("cannot wrap nil io.Reader")
 	}
 }
 
@@ -59,20 +101,70 @@ func NewReader(r io.Reader) *Reader {
 //after a successful read, then it is your responsibility to first call Err.
 func (r *Reader) Read(p []byte) (n int, err error) {
 	//if we had a previous error stored, return it and clear the store
+	//unless it was mapped away, in which case carry on with a fresh read
 	if r.err != nil {
-		return 0, r.Err()
+		if err = r.Err(); err != nil {
+			return 0, err
+		}
 	}
 
-	n, err = r.r.Read(p)
+	//an error discarded by surface leaves nothing to report,
+	//so try again rather than return 0, nil
+	for i := 0; i < maxNoProgress; i++ {
+		n, err = r.r.Read(p)
+		r.lastN, r.lastErr = n, err
 
-	//error and data returned, store error for next call
-	if n != 0 && err != nil {
-		r.err = err
-		return n, nil
+		if n != 0 {
+			r.off += int64(n)
+			r.metrics.BytesRead(n)
+
+			//error and data returned, store error for next call
+			r.err = err
+			return n, nil
+		}
+
+		//otherwise just return
+		if err == nil {
+			return 0, nil
+		}
+		if err = r.surface(err); err != nil {
+			return 0, err
+		}
+	}
+	return 0, io.ErrNoProgress
+}
+
+//surface applies any configured error transformations to err
+//and reports it before it is returned to the caller by Read or Err.
+func (r *Reader) surface(err error) error {
+	orig := err
+	err = r.mapped(err)
+	if err == io.EOF {
+		r.done = true
+		if r.eofErr != nil {
+			err = r.eofErr
+		}
+	} else if err != nil {
+		r.metrics.ReadError(err)
+	}
+
+	if r.aggregate {
+		if err == nil && orig != nil {
+			r.discarded = append(r.discarded, orig)
+		} else if err != nil && len(r.discarded) > 0 {
+			err = errors.Join(append(r.discarded, err)...)
+			r.discarded = nil
+		}
 	}
+	return err
+}
 
-	//otherwise just return
-	return n, err
+//mapped applies any error mapper to err.
+func (r *Reader) mapped(err error) error {
+	if err == nil || r.mapErr == nil {
+		return err
+	}
+	return r.mapErr(err)
 }
 
 //Err returns, then discards, any error stored from the last Read.
@@ -88,7 +180,91 @@ func (r *Reader) Err() error {
 	//or only applied to a particular read.
 	var err error
 	err, r.err = r.err, nil
-	return err
+	return r.surface(err)
+}
+
+//Done reports whether Read or Err has returned io.EOF,
+//or the error given to WithEOFError in its place.
+//
+//Done does not read or affect any stored error,
+//so it remains false after the last data has been read
+//until a Read reports the end of the stream.
+//This allows read loops of the form
+//
+//	for !r.Done() {
+//		p, err := simple.Read(r, buf)
+//		...
+//	}
+func (r *Reader) Done() bool {
+	return r.done
+}
+
+//SetError stores err as if it had been returned along with data
+//by the last read of the wrapped io.Reader,
+//so that it is returned by the next call to Read or Err.
+//If err is nil, any stored error is discarded.
+//
+//SetError is primarily an aid for testing how code handles
+//errors returned after data.
+func (r *Reader) SetError(err error) {
+	r.err = err
+}
+
+//LastRead returns the result of the most recent call to Read
+//on the wrapped io.Reader, exactly as it returned it,
+//or 0, nil if there has been none.
+//
+//Returning an error stored from a previous read does not call
+//the wrapped Read, so LastRead is unchanged by it.
+func (r *Reader) LastRead() (n int, err error) {
+	return r.lastN, r.lastErr
+}
+
+//Tell returns the offset of the next byte Read will deliver.
+//
+//If the wrapped io.Reader is an io.Seeker, it is asked for its current
+//offset, so the result includes anything read before it was wrapped
+//and reflects any seeks made on it directly.
+//Otherwise, Tell returns the number of bytes delivered by Read.
+//
+//The Reader itself never holds data it has read but not delivered,
+//so Tell needs no adjustment for it.
+//Data peeked or buffered by the wrapped io.Reader, as by a *bufio.Reader,
+//has not been delivered and is not counted.
+func (r *Reader) Tell() (int64, error) {
+	if s, ok := r.r.(io.Seeker); ok {
+		return s.Seek(0, io.SeekCurrent)
+	}
+	return r.off, nil
+}
+
+//errNotReaderAt is returned by ReadAt when the wrapped io.Reader
+//does not support it.
+var errNotReaderAt = fmt.Errorf("simple: wrapped reader is not an io.ReaderAt: %w", errors.ErrUnsupported)
+
+//ReadAt calls ReadAt on the wrapped io.Reader, if it is an io.ReaderAt,
+//and otherwise returns an error matching errors.ErrUnsupported.
+//
+//As with any io.ReaderAt, n < len(p) only when err != nil.
+//When n == len(p), err is always nil, even if the read ended
+//at the end of the input.
+//Errors are passed through any WithErrorMapper function,
+//but are not reported to any Metrics.
+//
+//ReadAt neither affects nor is affected by Read:
+//it does not change the position of the next Read
+//and does not return or clear stored errors.
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	ra, ok := r.r.(io.ReaderAt)
+	if !ok {
+		return 0, errNotReaderAt
+	}
+
+	n, err = ra.ReadAt(p, off)
+	if n == len(p) {
+		return n, nil
+	}
+	return n, r.mapped(err)
 }
 
 //Read grows p to its capacity, calls r.Read with p,
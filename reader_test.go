@@ -1,9 +1,12 @@
 package simple
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"testing"
+	"testing/iotest"
 )
 
 //basic is a simple io.Reader.
@@ -163,3 +166,161 @@ func ExampleReader_Err() {
 	// Hello, Wor
 	// World!
 }
+
+func TestReaderReadAt(t *testing.T) {
+	r := NewReader(strings.NewReader("Hello, World!"))
+	p := make([]byte, 5)
+
+	if _, err := r.Read(p); err != nil || string(p) != "Hello" {
+		t.Fatalf("got %q, %v", p, err)
+	}
+
+	if n, err := r.ReadAt(p, 7); n != 5 || err != nil || string(p) != "World" {
+		t.Errorf("got %d, %v, %q", n, err, p[:n])
+	}
+	//exactly reaching the end is not an error
+	if n, err := r.ReadAt(p[:1], 12); n != 1 || err != nil {
+		t.Errorf("got %d, %v", n, err)
+	}
+	if n, err := r.ReadAt(p, 10); n != 3 || err != io.EOF || string(p[:n]) != "ld!" {
+		t.Errorf("got %d, %v, %q", n, err, p[:n])
+	}
+
+	//the sequential position is unchanged
+	if _, err := r.Read(p); err != nil || string(p) != ", Wor" {
+		t.Errorf("got %q, %v", p, err)
+	}
+}
+
+func TestReaderReadAtUnsupported(t *testing.T) {
+	b := NewBasic("Hello")
+	r := NewReader(&b)
+	if _, err := r.ReadAt(make([]byte, 1), 0); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestReaderSetError(t *testing.T) {
+	errInjected := errors.New("injected")
+	r := NewReader(strings.NewReader("Hello, World!"))
+	p := make([]byte, 5)
+
+	r.SetError(errInjected)
+	if n, err := r.Read(p); n != 0 || err != errInjected {
+		t.Errorf("got %d, %v want 0, %v", n, err, errInjected)
+	}
+	if _, err := r.Read(p); err != nil || string(p) != "Hello" {
+		t.Errorf("got %q, %v", p, err)
+	}
+
+	r.SetError(errInjected)
+	r.SetError(nil)
+	if _, err := r.Read(p); err != nil || string(p) != ", Wor" {
+		t.Errorf("got %q, %v", p, err)
+	}
+}
+
+func TestReaderTell(t *testing.T) {
+	sr := strings.NewReader("Hello, World!")
+	b := NewBasic("Hello, World!")
+	for name, r := range map[string]*Reader{"seeker": NewReader(sr), "counted": NewReader(&b)} {
+		p := make([]byte, 10)
+		for _, want := range []int64{10, 13, 13} {
+			r.Read(p)
+			if got, err := r.Tell(); got != want || err != nil {
+				t.Errorf("%s: got %d, %v want %d", name, got, err, want)
+			}
+		}
+	}
+
+	//seeks on the underlying io.Seeker are reflected
+	if _, err := sr.Seek(7, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := NewReader(sr).Tell(); got != 7 || err != nil {
+		t.Errorf("got %d, %v want 7", got, err)
+	}
+}
+
+func TestReaderLastRead(t *testing.T) {
+	b := NewBasic("Hello, World!")
+	r := NewReader(&b)
+	p := make([]byte, 10)
+
+	if n, err := r.LastRead(); n != 0 || err != nil {
+		t.Errorf("before reading: got %d, %v", n, err)
+	}
+
+	r.Read(p)
+	if n, err := r.LastRead(); n != 10 || err != nil {
+		t.Errorf("got %d, %v want 10, nil", n, err)
+	}
+
+	//Read hides the error, LastRead does not
+	if n, err := r.Read(p); n != 3 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if n, err := r.LastRead(); n != 3 || err != io.EOF {
+		t.Errorf("got %d, %v want 3, EOF", n, err)
+	}
+
+	//returning the stored EOF leaves LastRead as it was
+	r.Read(p)
+	if n, err := r.LastRead(); n != 3 || err != io.EOF {
+		t.Errorf("got %d, %v want 3, EOF", n, err)
+	}
+}
+
+func TestDone(t *testing.T) {
+	r := NewReader(&dataErr{"abc", io.EOF})
+	if r.Done() {
+		t.Fatal("done before reading")
+	}
+
+	//the data comes with io.EOF, which is stored, not yet returned
+	if p, err := Read(r, make([]byte, 10)); err != nil || string(p) != "abc" {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	if r.Done() {
+		t.Error("done before io.EOF was returned")
+	}
+
+	if _, err := r.Read(make([]byte, 10)); err != io.EOF {
+		t.Fatalf("got %v want io.EOF", err)
+	}
+	if !r.Done() {
+		t.Error("not done after io.EOF")
+	}
+
+	//other errors are not the end
+	r = NewReader(iotest.ErrReader(errTruncated))
+	r.Read(make([]byte, 1))
+	if r.Done() {
+		t.Error("done after an error")
+	}
+
+	//nor is an error mapped away
+	r = NewReader(strings.NewReader(""), WithErrorMapper(func(err error) error {
+		return io.ErrUnexpectedEOF
+	}))
+	r.Read(make([]byte, 1))
+	if r.Done() {
+		t.Error("done after io.EOF was mapped away")
+	}
+}
+
+func TestDoneLoop(t *testing.T) {
+	r := NewReader(iotest.HalfReader(strings.NewReader("Hello, World!")))
+	var got []byte
+	buf := make([]byte, 4)
+	for !r.Done() {
+		p, err := Read(r, buf)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		got = append(got, p...)
+	}
+	if string(got) != "Hello, World!" {
+		t.Errorf("got %q", got)
+	}
+}
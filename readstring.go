@@ -0,0 +1,79 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+//ErrInvalidString is returned, wrapped with more detail, by ReadStringN
+//for a field that is not valid in its Encoding.
+var ErrInvalidString = errors.New("simple: invalid string")
+
+//Encoding is the encoding of a string read by ReadStringN.
+type Encoding int
+
+const (
+	UTF8   Encoding = iota //UTF-8, which must be valid
+	ASCII                  //7-bit ASCII
+	Latin1                 //ISO 8859-1, each byte a code point
+)
+
+//WithTrimNUL makes ReadStringN remove trailing NUL bytes from each field,
+//as used to pad fixed-width strings in C structs.
+func WithTrimNUL() Option {
+	return func(r *Reader) {
+		r.trimNUL = true
+	}
+}
+
+//ReadStringN reads a field of exactly n bytes and returns it decoded from enc
+//as a UTF-8 string.
+//Trailing NULs are removed first, if the Reader was created WithTrimNUL.
+//
+//ReadStringN returns io.EOF only if the stream ends before the field begins.
+//If it ends within the field, ReadStringN returns io.ErrUnexpectedEOF.
+//A field that is not valid UTF-8, or ASCII, consumes the field
+//and returns an error wrapping ErrInvalidString.
+//
+//ReadStringN panics if n is negative or enc is unknown.
+func (r *Reader) ReadStringN(n int, enc Encoding) (string, error) {
+	if n < 0 {
+		panic("string length cannot be negative")
+	}
+	if enc < UTF8 || enc > Latin1 {
+		panic("unknown Encoding")
+	}
+
+	buf := r.allocBuf(n)
+	defer r.freeBuf(buf)
+	field := buf
+	if _, err := io.ReadFull(r, field); err != nil {
+		return "", err
+	}
+	if r.trimNUL {
+		field = bytes.TrimRight(field, "\x00")
+	}
+
+	switch enc {
+	case UTF8:
+		if !utf8.Valid(field) {
+			return "", fmt.Errorf("%w: not UTF-8: %q", ErrInvalidString, field)
+		}
+	case ASCII:
+		for i, b := range field {
+			if b >= utf8.RuneSelf {
+				return "", fmt.Errorf("%w: byte %#x at %d is not ASCII", ErrInvalidString, b, i)
+			}
+		}
+	case Latin1:
+		s := make([]rune, len(field))
+		for i, b := range field {
+			s[i] = rune(b)
+		}
+		return string(s), nil
+	}
+	return string(field), nil
+}
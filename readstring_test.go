@@ -0,0 +1,68 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadStringN(t *testing.T) {
+	//three fixed fields: a padded name, a UTF-8 city, a Latin-1 word
+	in := "bob\x00\x00\x00\x00\x00" + "Zürich\x00" + "caf\xe9"
+	for _, opts := range [][]Option{nil, {WithTrimNUL()}} {
+		r := NewReader(iotest.OneByteReader(strings.NewReader(in)), opts...)
+		name, err := r.ReadStringN(8, ASCII)
+		if err != nil {
+			t.Fatal(err)
+		}
+		city, err := r.ReadStringN(8, UTF8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		word, err := r.ReadStringN(4, Latin1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantName, wantCity := "bob\x00\x00\x00\x00\x00", "Zürich\x00"
+		if opts != nil {
+			wantName, wantCity = "bob", "Zürich"
+		}
+		if name != wantName || city != wantCity || word != "café" {
+			t.Errorf("got %q, %q, %q", name, city, word)
+		}
+		if _, err := r.ReadStringN(1, ASCII); err != io.EOF {
+			t.Errorf("got %v want io.EOF", err)
+		}
+	}
+}
+
+func TestReadStringNShort(t *testing.T) {
+	r := NewReader(strings.NewReader("abc"))
+	if s, err := r.ReadStringN(4, UTF8); s != "" || err != io.ErrUnexpectedEOF {
+		t.Errorf("got %q, %v", s, err)
+	}
+	if s, err := NewReader(strings.NewReader("")).ReadStringN(0, UTF8); s != "" || err != nil {
+		t.Errorf("got %q, %v", s, err)
+	}
+}
+
+func TestReadStringNInvalid(t *testing.T) {
+	r := NewReader(strings.NewReader("Zü\xffok"))
+	if _, err := r.ReadStringN(3, ASCII); !errors.Is(err, ErrInvalidString) {
+		t.Errorf("got %v", err)
+	}
+	//"\xbc" is the second half of ü
+	if _, err := NewReader(strings.NewReader("Z\xbc")).ReadStringN(2, UTF8); !errors.Is(err, ErrInvalidString) {
+		t.Errorf("got %v", err)
+	}
+	//the invalid field is consumed
+	if _, err := r.ReadStringN(1, UTF8); !errors.Is(err, ErrInvalidString) {
+		t.Errorf("got %v", err)
+	}
+	if s, err := r.ReadStringN(2, UTF8); s != "ok" || err != nil {
+		t.Errorf("got %q, %v", s, err)
+	}
+}
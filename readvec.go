@@ -0,0 +1,87 @@
+package simple
+
+import "io"
+
+//VectorReader is implemented by readers that can read
+//into several buffers at once, such as with a readv system call.
+//
+//ReadVec reads up to the total length of bufs into bufs, in order,
+//and otherwise behaves like Read.
+type VectorReader interface {
+	ReadVec(bufs [][]byte) (n int, err error)
+}
+
+//ReadVec fills each of bufs completely, in order, from r
+//and returns the number of bytes read in total.
+//It is the scatter read counterpart of io.ReadFull.
+//
+//If r is a VectorReader, its ReadVec is used with the buffers
+//that remain to be filled.
+//Otherwise each buffer is filled in turn.
+//
+//ReadVec returns io.EOF only if no bytes were read.
+//If r ends after some but not all the bytes, it returns io.ErrUnexpectedEOF.
+func ReadVec(r io.Reader, bufs [][]byte) (int, error) {
+	vr, ok := r.(VectorReader)
+	if !ok {
+		return readVecLoop(r, bufs)
+	}
+
+	//the buffers are trimmed as they fill, so copy the caller's slice
+	bufs = append([][]byte(nil), bufs...)
+	total := 0
+	for stalls := 0; ; {
+		for len(bufs) > 0 && len(bufs[0]) == 0 {
+			bufs = bufs[1:]
+		}
+		if len(bufs) == 0 {
+			return total, nil
+		}
+
+		n, err := vr.ReadVec(bufs)
+		total += n
+		for left := n; left > 0; {
+			m := min(left, len(bufs[0]))
+			bufs[0] = bufs[0][m:]
+			left -= m
+			if len(bufs[0]) == 0 {
+				bufs = bufs[1:]
+			}
+		}
+		if len(bufs) == 0 {
+			return total, nil
+		}
+		if err != nil {
+			return total, unexpectedEOF(err, total)
+		}
+
+		if n == 0 {
+			if stalls++; stalls == maxNoProgress {
+				return total, io.ErrNoProgress
+			}
+		} else {
+			stalls = 0
+		}
+	}
+}
+
+func readVecLoop(r io.Reader, bufs [][]byte) (int, error) {
+	total := 0
+	for _, b := range bufs {
+		n, err := io.ReadFull(r, b)
+		total += n
+		if err != nil {
+			return total, unexpectedEOF(err, total)
+		}
+	}
+	return total, nil
+}
+
+//unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF
+//if any bytes have been read.
+func unexpectedEOF(err error, total int) error {
+	if total > 0 && err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
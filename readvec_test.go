@@ -0,0 +1,82 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//vecReader is a VectorReader that fills at most max bytes per call.
+type vecReader struct {
+	r     io.Reader
+	max   int
+	calls int
+}
+
+func (v *vecReader) Read(p []byte) (int, error) {
+	return v.r.Read(p)
+}
+
+func (v *vecReader) ReadVec(bufs [][]byte) (int, error) {
+	v.calls++
+	total := 0
+	for _, b := range bufs {
+		b = b[:min(len(b), v.max-total)]
+		n, err := io.ReadFull(v.r, b)
+		total += n
+		if err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return total, err
+		}
+		if total == v.max {
+			break
+		}
+	}
+	return total, nil
+}
+
+func TestReadVec(t *testing.T) {
+	const in = "HEADpayload!rest"
+	readers := map[string]func() io.Reader{
+		"onebyte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(in)) },
+		"half":    func() io.Reader { return iotest.HalfReader(strings.NewReader(in)) },
+		"vec":     func() io.Reader { return &vecReader{r: strings.NewReader(in), max: 3} },
+	}
+	for name, mk := range readers {
+		r := mk()
+		header, empty, payload := make([]byte, 4), []byte{}, make([]byte, 8)
+		bufs := [][]byte{header, empty, payload}
+		n, err := ReadVec(r, bufs)
+		if n != 12 || err != nil {
+			t.Fatalf("%s: got %d, %v", name, n, err)
+		}
+		if string(header) != "HEAD" || string(payload) != "payload!" {
+			t.Errorf("%s: got %q %q", name, header, payload)
+		}
+		if len(bufs[0]) != 4 || len(bufs[2]) != 8 {
+			t.Errorf("%s: modified the caller's buffers", name)
+		}
+
+		//short
+		n, err = ReadVec(r, [][]byte{make([]byte, 2), make([]byte, 4)})
+		if n != 4 || err != io.ErrUnexpectedEOF {
+			t.Errorf("%s: got %d, %v want 4, io.ErrUnexpectedEOF", name, n, err)
+		}
+		if n, err := ReadVec(r, [][]byte{make([]byte, 2)}); n != 0 || err != io.EOF {
+			t.Errorf("%s: got %d, %v want 0, io.EOF", name, n, err)
+		}
+	}
+}
+
+func TestReadVecUsesVectorReader(t *testing.T) {
+	v := &vecReader{r: strings.NewReader("abcdefgh"), max: 100}
+	if n, err := ReadVec(v, [][]byte{make([]byte, 3), make([]byte, 5)}); n != 8 || err != nil {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if v.calls != 1 {
+		t.Errorf("ReadVec called %d times, want 1", v.calls)
+	}
+}
@@ -0,0 +1,119 @@
+package simple
+
+import (
+	"compress/gzip"
+	"io"
+	"runtime"
+	"sync"
+)
+
+//RecompressReader is a Reader that recompresses a gzip stream
+//in a background goroutine.
+type RecompressReader struct {
+	*Reader
+	c *recompressReader
+}
+
+//NewRecompressReader wraps r, a gzip stream, in a RecompressReader
+//that delivers its contents compressed again as a single gzip member
+//at the given level, such as gzip.BestSpeed.
+//
+//r is read as by NewMultiGzipReader,
+//and any error in it is returned after the output preceding it.
+//The header of r is not preserved.
+//
+//The decompression and compression are done by a goroutine,
+//started by the first Read, feeding a pipe.
+//It is stopped by Close,
+//or once the RecompressReader is no longer reachable,
+//although a read of r already in progress must still finish.
+//
+//NewRecompressReader panics if level is not a valid gzip level.
+func NewRecompressReader(r io.Reader, level int) *RecompressReader {
+	must(r)
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		panic(err)
+	}
+	pr, pw := io.Pipe()
+	c := &recompressReader{
+		r:      r,
+		level:  level,
+		pr:     pr,
+		pw:     pw,
+		closed: make(chan struct{}),
+	}
+	rr := &RecompressReader{
+		Reader: NewReader(c),
+		c:      c,
+	}
+	//the goroutine only refers to r and pw,
+	//so the *Reader can become unreachable while it runs
+	runtime.SetFinalizer(rr.Reader, func(*Reader) { pr.CloseWithError(ErrClosed) })
+	return rr
+}
+
+//Close stops the goroutine and the reader.
+//Any Read waiting for data returns ErrClosed, as do all later Reads.
+//If the wrapped io.Reader is an io.Closer, it is closed.
+//
+//Close may be called concurrently with Read.
+func (r *RecompressReader) Close() error {
+	return r.c.close()
+}
+
+type recompressReader struct {
+	r       io.Reader
+	level   int
+	pr      *io.PipeReader
+	pw      *io.PipeWriter
+	started bool
+
+	once   sync.Once
+	closed chan struct{}
+	err    error //from closing r
+}
+
+func (c *recompressReader) Read(p []byte) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, ErrClosed
+	default:
+	}
+	if !c.started {
+		c.started = true
+		go recompress(c.r, c.pw, c.level)
+	}
+
+	n, err := c.pr.Read(p)
+	if err == io.ErrClosedPipe {
+		err = ErrClosed
+	}
+	return n, err
+}
+
+//recompress writes r, decompressed and recompressed at level, to pw.
+func recompress(r io.Reader, pw *io.PipeWriter, level int) {
+	gz, err := newGzipReader(r, true)
+	if err != nil {
+		pw.CloseWithError(err)
+		return
+	}
+	zw, _ := gzip.NewWriterLevel(pw, level)
+	_, err = io.Copy(zw, gz)
+	if err == nil {
+		err = zw.Close()
+	}
+	//a nil err closes pw with io.EOF
+	pw.CloseWithError(err)
+}
+
+func (c *recompressReader) close() error {
+	c.once.Do(func() {
+		close(c.closed)
+		c.pr.CloseWithError(ErrClosed)
+		if cl, ok := c.r.(io.Closer); ok {
+			c.err = cl.Close()
+		}
+	})
+	return c.err
+}
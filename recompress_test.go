@@ -0,0 +1,68 @@
+package simple
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRecompressReader(t *testing.T) {
+	want := strings.Repeat("Hello, World! ", 1000)
+	for _, level := range []int{gzip.NoCompression, gzip.BestSpeed, gzip.BestCompression} {
+		r := NewRecompressReader(bytes.NewReader(gzipped(t, want)), level)
+		out, err := io.ReadAll(iotest.HalfReader(r))
+		if err != nil {
+			t.Fatalf("level %d: %v", level, err)
+		}
+		if level == gzip.NoCompression && len(out) < len(want) {
+			t.Errorf("level %d: %d bytes is compressed", level, len(out))
+		}
+
+		zr, err := gzip.NewReader(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("level %d: %v", level, err)
+		}
+		got, err := io.ReadAll(zr)
+		if err != nil || string(got) != want {
+			t.Errorf("level %d: got %d bytes, %v", level, len(got), err)
+		}
+		r.Close()
+	}
+}
+
+func TestRecompressReaderBadInput(t *testing.T) {
+	r := NewRecompressReader(strings.NewReader("not gzip"), gzip.BestSpeed)
+	defer r.Close()
+	if _, err := io.ReadAll(r); !errors.Is(err, ErrGzipHeader) {
+		t.Errorf("got %v", err)
+	}
+
+	gz := gzipped(t, "Hello, World!")
+	r = NewRecompressReader(bytes.NewReader(gz[:len(gz)-3]), gzip.BestSpeed)
+	defer r.Close()
+	if _, err := io.ReadAll(r); err != io.ErrUnexpectedEOF {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestRecompressReaderClose(t *testing.T) {
+	c := &closeCounter{Reader: bytes.NewReader(gzipped(t, strings.Repeat("x", 1<<20)))}
+	r := NewRecompressReader(c, gzip.BestSpeed)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if c.closes != 1 {
+		t.Errorf("closed %d times", c.closes)
+	}
+	if _, err := r.Read(make([]byte, 10)); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
@@ -0,0 +1,136 @@
+package simple
+
+import (
+	"io"
+	"regexp"
+)
+
+//DefaultMaxRedactMatch is the longest match NewRedactReader redacts.
+const DefaultMaxRedactMatch = 4096
+
+//NewRedactReader returns a Reader that replaces each match in r
+//of any of patterns with replacement,
+//such as to scrub secrets from logs.
+//
+//Matches may span any number of reads of r,
+//but only matches of at most DefaultMaxRedactMatch bytes are redacted.
+//See NewRedactReaderSize.
+func NewRedactReader(r io.Reader, patterns []*regexp.Regexp, replacement []byte) *Reader {
+	return NewRedactReaderSize(r, patterns, replacement, DefaultMaxRedactMatch)
+}
+
+//NewRedactReaderSize is like NewRedactReader except that only matches
+//of at most maxMatch bytes are redacted.
+//Longer matches, and empty matches, are left as is,
+//including any text within them that would otherwise match.
+//
+//Up to maxMatch bytes are withheld while waiting to see if
+//they begin a match, so memory is bounded by maxMatch.
+//A match that runs on past what is withheld,
+//and so is too long, is followed across reads:
+//a match that begins where it ends is taken to continue it.
+//
+//Where matches overlap, the one that begins first is used,
+//or, of those that begin at the same byte,
+//the one from the earliest pattern.
+//
+//Patterns are matched against a window of the stream,
+//so ^, $, \A, \z, and \b may match at the edges of the window
+//rather than of the stream or line.
+//
+//NewRedactReaderSize panics if maxMatch < 1.
+func NewRedactReaderSize(r io.Reader, patterns []*regexp.Regexp, replacement []byte, maxMatch int) *Reader {
+	if maxMatch < 1 {
+		panic("max match length must be positive")
+	}
+	return newTransformReader(r, &redactTransform{
+		patterns: patterns,
+		repl:     append([]byte(nil), replacement...),
+		max:      maxMatch,
+	})
+}
+
+type redactTransform struct {
+	patterns []*regexp.Regexp
+	repl     []byte
+	max      int
+	buf      []byte //withheld input
+
+	//buf[:long] is the end of a match that is too long,
+	//which runs on past the end of buf if cont
+	long int
+	cont bool
+}
+
+func (t *redactTransform) push(out, in []byte) ([]byte, error) {
+	t.buf = append(t.buf, in...)
+	return t.drain(out, false), nil
+}
+
+func (t *redactTransform) flush(out []byte) ([]byte, error) {
+	return t.drain(out, true), nil
+}
+
+//drain appends the redacted output for the start of buf
+//that can no longer begin a match that has not yet been seen in full,
+//or all of buf if final.
+func (t *redactTransform) drain(out []byte, final bool) []byte {
+	for {
+		start, end, ok := t.find(final)
+		//before the end of the stream, a match can only be trusted
+		//if enough follows it to show that it is not too long
+		if !ok || !final && len(t.buf)-start <= t.max {
+			break
+		}
+		out = append(out, t.buf[:start]...)
+		out = append(out, t.repl...)
+		t.buf = t.buf[end:]
+		t.long, t.cont = 0, false
+	}
+
+	n := len(t.buf)
+	if !final {
+		n = max(0, len(t.buf)-t.max)
+	}
+	out = append(out, t.buf[:n]...)
+	t.buf = append(t.buf[:0], t.buf[n:]...)
+	t.long = max(0, t.long-n)
+	return out
+}
+
+//find returns the first match in buf after buf[:long]
+//of length 1 to max,
+//and records any longer match before it in long.
+func (t *redactTransform) find(final bool) (start, end int, ok bool) {
+	for {
+		start, end, ok = t.first(t.long)
+		if !ok {
+			return 0, 0, false
+		}
+		if end-start <= t.max && !(t.cont && start == t.long) {
+			return start, end, true
+		}
+		t.long, t.cont = end, !final && end == len(t.buf)
+	}
+}
+
+//first returns the first non-empty match in buf[off:].
+func (t *redactTransform) first(off int) (start, end int, ok bool) {
+	start = len(t.buf)
+	for _, re := range t.patterns {
+		//only matches before the best so far can improve on it
+		for at := off; at < start; {
+			loc := re.FindIndex(t.buf[at:])
+			if loc == nil || at+loc[0] >= start {
+				break
+			}
+			s, e := at+loc[0], at+loc[1]
+			if e > s {
+				start, end, ok = s, e, true
+				break
+			}
+			at = s + 1
+		}
+	}
+	return start, end, ok
+}
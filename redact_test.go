@@ -0,0 +1,93 @@
+package simple
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func redactAll(t *testing.T, in string, sizes []int, patterns []*regexp.Regexp, maxMatch int) string {
+	t.Helper()
+	r := NewRedactReaderSize(NewScriptedReader([]byte(in), sizes), patterns, []byte("***"), maxMatch)
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(got)
+}
+
+func TestRedactReader(t *testing.T) {
+	const (
+		in   = "user=bob password=hunter2 token=abc123def, password=x token=q"
+		want = "user=bob *** *** *** ***"
+	)
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`password=\S+`),
+		regexp.MustCompile(`token=[a-z0-9]+,?`),
+	}
+	//the secrets are split across every possible read boundary
+	for i := 0; i <= len(in); i++ {
+		for _, size := range []int{1, 5, 64} {
+			if got := redactAll(t, in, []int{i, size}, patterns, 32); got != want {
+				t.Errorf("split at %d then %d: got %q", i, size, got)
+			}
+		}
+	}
+}
+
+func TestRedactReaderDefault(t *testing.T) {
+	in := strings.Repeat("x", 5000) + "key=s3cret " + strings.Repeat("y", 5000)
+	r := NewRedactReader(NewScriptedReader([]byte(in), []int{5003, 7}), []*regexp.Regexp{regexp.MustCompile(`key=\w+`)}, []byte("key=?"))
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Replace(in, "s3cret", "?", 1); string(got) != want {
+		t.Errorf("got %d bytes, want %d", len(got), len(want))
+	}
+}
+
+func TestRedactReaderTooLong(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		maxMatch int
+		in, want string
+	}{
+		{`<[^>]*>`, 8, "<short> <much too long> <ok>", "*** <much too long> ***"},
+		//no part of a match that is too long is redacted
+		{`a+`, 4, "x aaaaaaaa y", "x aaaaaaaa y"},
+		{`a+`, 4, "x aaaaaaaa", "x aaaaaaaa"},
+		{`a+`, 4, "x aaaa y aaaaa aa", "x *** y aaaaa ***"},
+		{`\S+=\S+`, 4, "k=vvvvvvvvvvvv k=v", "k=vvvvvvvvvvvv ***"},
+	}
+	for _, tt := range tests {
+		patterns := []*regexp.Regexp{regexp.MustCompile(tt.pattern)}
+		for i := 0; i <= len(tt.in); i++ {
+			for _, size := range []int{1, 3, 64} {
+				if got := redactAll(t, tt.in, []int{i, size}, patterns, tt.maxMatch); got != tt.want {
+					t.Errorf("%q split at %d then %d: got %q", tt.in, i, size, got)
+				}
+			}
+		}
+	}
+}
+
+func TestRedactReaderOverlap(t *testing.T) {
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`bc`),
+		regexp.MustCompile(`abc|b`),
+		regexp.MustCompile(`x*`), //only empty matches here
+	}
+	for _, sizes := range [][]int{{1}, {2}, {100}} {
+		if got := redactAll(t, "abcd bcd", sizes, patterns, 4); got != "***d ***d" {
+			t.Errorf("%v: got %q", sizes, got)
+		}
+	}
+}
+
+func TestRedactReaderEmpty(t *testing.T) {
+	if got := redactAll(t, "", []int{1}, []*regexp.Regexp{regexp.MustCompile(`a`)}, 4); got != "" {
+		t.Errorf("got %q", got)
+	}
+}
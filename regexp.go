@@ -0,0 +1,53 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+//ErrNoMatch is returned, wrapped with more detail, by ReadUntilRegexp
+//when maxScan bytes are read without a match.
+var ErrNoMatch = errors.New("simple: no match")
+
+//ReadUntilRegexp reads from r until the bytes read match re
+//and returns the bytes up to and including the end of the match.
+//
+//r is read one byte at a time, and re is tried after each byte,
+//so nothing is read past the first point at which re matches.
+//This means a pattern that could match more,
+//such as `a+`, matches as little as it can.
+//
+//If maxScan bytes are read without a match, ReadUntilRegexp returns them
+//and an error wrapping ErrNoMatch.
+//If r ends first, it returns the bytes read and io.ErrUnexpectedEOF,
+//or io.EOF if there were none.
+//
+//ReadUntilRegexp panics if maxScan is not positive.
+func ReadUntilRegexp(r io.Reader, re *regexp.Regexp, maxScan int) ([]byte, error) {
+	must(r)
+	if maxScan <= 0 {
+		panic("maxScan must be positive")
+	}
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: NewReader(r)}
+	}
+
+	var buf []byte
+	for len(buf) < maxScan {
+		b, err := br.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(buf) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return buf, err
+		}
+		buf = append(buf, b)
+		if loc := re.FindIndex(buf); loc != nil {
+			return buf[:loc[1]], nil
+		}
+	}
+	return buf, fmt.Errorf("%w for %v in %d bytes", ErrNoMatch, re, maxScan)
+}
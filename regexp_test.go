@@ -0,0 +1,54 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadUntilRegexp(t *testing.T) {
+	re := regexp.MustCompile(`\r?\n\r?\n`)
+	const in = "HTTP/1.1 200 OK\r\nA: b\r\n\r\nbody"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		got, err := ReadUntilRegexp(wrap(strings.NewReader(in)), re, 64)
+		if err != nil || string(got) != "HTTP/1.1 200 OK\r\nA: b\r\n\r\n" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//nothing past the match is consumed
+	sr := strings.NewReader(in)
+	if _, err := ReadUntilRegexp(iotest.HalfReader(sr), re, 64); err != nil {
+		t.Fatal(err)
+	}
+	if rest, _ := io.ReadAll(sr); string(rest) != "body" {
+		t.Errorf("left %q want %q", rest, "body")
+	}
+}
+
+func TestReadUntilRegexpShortest(t *testing.T) {
+	got, err := ReadUntilRegexp(strings.NewReader("xxaaa"), regexp.MustCompile(`a+`), 10)
+	if err != nil || string(got) != "xxa" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestReadUntilRegexpNoMatch(t *testing.T) {
+	re := regexp.MustCompile(`END`)
+	got, err := ReadUntilRegexp(strings.NewReader("0123456789END"), re, 8)
+	if !errors.Is(err, ErrNoMatch) || string(got) != "01234567" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	got, err = ReadUntilRegexp(strings.NewReader("EN"), re, 8)
+	if err != io.ErrUnexpectedEOF || string(got) != "EN" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	if _, err := ReadUntilRegexp(strings.NewReader(""), re, 8); err != io.EOF {
+		t.Errorf("got %v want io.EOF", err)
+	}
+}
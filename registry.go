@@ -0,0 +1,128 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"sync"
+)
+
+//Decompressor returns a reader of the decompressed contents of r.
+//r begins with the magic bytes the Decompressor was registered with.
+type Decompressor func(r io.Reader) (io.Reader, error)
+
+//CompressionRegistry maps magic byte prefixes to Decompressors
+//for NewRegistryReader.
+//
+//The zero value has no registrations.
+//A CompressionRegistry is safe for concurrent use.
+type CompressionRegistry struct {
+	mu      sync.RWMutex
+	formats []registeredFormat
+	longest int
+}
+
+type registeredFormat struct {
+	magic []byte
+	fn    Decompressor
+}
+
+//NewCompressionRegistry returns a CompressionRegistry with gzip,
+//read as by NewMultiGzipReader, and zlib registered.
+func NewCompressionRegistry() *CompressionRegistry {
+	reg := &CompressionRegistry{}
+	reg.Register([]byte{0x1f, 0x8b, 8}, func(r io.Reader) (io.Reader, error) {
+		return newGzipReader(r, true)
+	})
+	//the zlib headers written by each compression level
+	for _, flg := range []byte{0x01, 0x5e, 0x9c, 0xda} {
+		reg.Register([]byte{0x78, flg}, func(r io.Reader) (io.Reader, error) {
+			return zlib.NewReader(r)
+		})
+	}
+	return reg
+}
+
+//Register adds fn as the Decompressor for streams beginning with magic,
+//replacing any already registered for exactly magic.
+//If the prefixes of more than one registration match,
+//the longest is used.
+//
+//Register panics if magic is empty or fn is nil.
+func (c *CompressionRegistry) Register(magic []byte, fn Decompressor) {
+	if len(magic) == 0 {
+		panic("magic cannot be empty")
+	}
+	if fn == nil {
+		panic("nil Decompressor")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, f := range c.formats {
+		if bytes.Equal(f.magic, magic) {
+			c.formats[i].fn = fn
+			return
+		}
+	}
+	c.formats = append(c.formats, registeredFormat{
+		magic: append([]byte(nil), magic...),
+		fn:    fn,
+	})
+	c.longest = max(c.longest, len(magic))
+}
+
+//lookup returns the Decompressor for the longest prefix of b registered,
+//or nil if there is none.
+func (c *CompressionRegistry) lookup(b []byte) Decompressor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var best registeredFormat
+	for _, f := range c.formats {
+		if len(f.magic) > len(best.magic) && bytes.HasPrefix(b, f.magic) {
+			best = f
+		}
+	}
+	return best.fn
+}
+
+//span returns the length of the longest magic registered.
+func (c *CompressionRegistry) span() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.longest
+}
+
+//NewRegistryReader returns a Reader of the decompressed contents of r
+//using the Decompressor in reg registered for the longest prefix of r,
+//or of r unchanged if none match.
+//Unlike NewAutoDecompressReader, the formats recognized can be extended.
+//
+//Errors from the Decompressor are returned as is.
+//
+//r is buffered, so more may be read from r than the stream.
+func NewRegistryReader(r io.Reader, reg *CompressionRegistry) (*Reader, error) {
+	must(r)
+	if reg == nil {
+		panic("nil CompressionRegistry")
+	}
+	br := bufio.NewReaderSize(r, max(4096, reg.span()))
+	magic, err := br.Peek(reg.span())
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	fn := reg.lookup(magic)
+	if fn == nil {
+		return NewReader(br), nil
+	}
+	dr, err := fn(br)
+	if err != nil {
+		return nil, err
+	}
+	if sr, ok := dr.(*Reader); ok {
+		return sr, nil
+	}
+	return NewReader(dr), nil
+}
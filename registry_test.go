@@ -0,0 +1,89 @@
+package simple
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegistryReaderBuiltin(t *testing.T) {
+	var zbuf bytes.Buffer
+	zw, _ := zlib.NewWriterLevel(&zbuf, zlib.BestSpeed)
+	zw.Write([]byte("Hello, World!"))
+	zw.Close()
+
+	reg := NewCompressionRegistry()
+	tests := map[string]string{
+		"gzip":  string(gzipped(t, "Hello, World!")),
+		"zlib":  zbuf.String(),
+		"plain": "Hello, World!",
+		"empty": "",
+	}
+	for name, in := range tests {
+		r, err := NewRegistryReader(strings.NewReader(in), reg)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		want := "Hello, World!"
+		if in == "" {
+			want = ""
+		}
+		if got, err := io.ReadAll(r); err != nil || string(got) != want {
+			t.Errorf("%s: got %q, %v", name, got, err)
+		}
+	}
+}
+
+func TestRegistryReaderCustom(t *testing.T) {
+	rot := func(r io.Reader) (io.Reader, error) {
+		if _, err := io.CopyN(io.Discard, r, 4); err != nil {
+			return nil, err
+		}
+		return NewROT13Reader(r), nil
+	}
+	errBad := errors.New("bad version")
+	bad := func(r io.Reader) (io.Reader, error) {
+		return nil, errBad
+	}
+
+	reg := NewCompressionRegistry()
+	reg.Register([]byte("ROT"), bad)
+	reg.Register([]byte("ROT1"), rot)
+
+	r, err := NewRegistryReader(strings.NewReader("ROT1Uryyb"), reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || string(got) != "Hello" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	//the shorter prefix
+	if _, err := NewRegistryReader(strings.NewReader("ROT2Uryyb"), reg); err != errBad {
+		t.Errorf("got %v want %v", err, errBad)
+	}
+
+	//replacing a registration
+	reg.Register([]byte("ROT"), rot)
+	r, err = NewRegistryReader(strings.NewReader("ROT2Uryyb"), reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != "Hello" {
+		t.Errorf("got %q", got)
+	}
+
+	//the zero value has no registrations
+	var empty CompressionRegistry
+	r, err = NewRegistryReader(strings.NewReader("ROT1Uryyb"), &empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != "ROT1Uryyb" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	//ErrSequenceGap is returned, wrapped with more detail, by a Reader
+	//from NewReorderReader when a missing chunk does not arrive in time.
+	ErrSequenceGap = errors.New("simple: gap in chunk sequence")
+	//ErrDuplicateChunk is returned, wrapped with more detail, by a Reader
+	//from NewReorderReader for a chunk whose sequence number has been seen.
+	ErrDuplicateChunk = errors.New("simple: duplicate chunk")
+)
+
+//SeqChunk is a chunk of a stream, for NewReorderReader.
+type SeqChunk struct {
+	Seq  uint64 //the position of the chunk in the stream, from 0
+	Data []byte
+}
+
+//NewReorderReader returns a Reader of the stream reassembled from
+//the chunks received from in, delivered in order of their Seq.
+//
+//Up to window chunks that arrive before those preceding them are held
+//until the gap is filled.
+//If more are needed, or in is closed with a gap remaining,
+//the Reader returns an error wrapping ErrSequenceGap.
+//A chunk with the Seq of one already received
+//results in an error wrapping ErrDuplicateChunk.
+//Either error stops the stream.
+//Otherwise, once in is closed, the Reader returns io.EOF.
+//
+//The Data of a chunk is delivered as is, rather than copied,
+//so it must not be modified afterward.
+//
+//NewReorderReader panics if in is nil or window is negative.
+func NewReorderReader(in <-chan SeqChunk, window int) *Reader {
+	if in == nil {
+		panic("nil chunk channel")
+	}
+	if window < 0 {
+		panic("reorder window cannot be negative")
+	}
+	return NewReader(&reorderReader{
+		in:      in,
+		window:  window,
+		pending: map[uint64][]byte{},
+	})
+}
+
+type reorderReader struct {
+	in      <-chan SeqChunk
+	window  int
+	pending map[uint64][]byte //chunks received ahead of next
+	next    uint64            //Seq of the next chunk to deliver
+	data    []byte            //the undelivered part of the current chunk
+	err     error
+}
+
+func (r *reorderReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if data, ok := r.pending[r.next]; ok {
+			delete(r.pending, r.next)
+			r.data = data
+			r.next++
+			continue
+		}
+		r.err = r.receive()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+//receive waits for the next chunk and stores it in pending.
+func (r *reorderReader) receive() error {
+	c, ok := <-r.in
+	if !ok {
+		if len(r.pending) > 0 {
+			return fmt.Errorf("%w: chunk %d missing at end of stream", ErrSequenceGap, r.next)
+		}
+		return io.EOF
+	}
+
+	if _, dup := r.pending[c.Seq]; dup || c.Seq < r.next {
+		return fmt.Errorf("%w: %d", ErrDuplicateChunk, c.Seq)
+	}
+	r.pending[c.Seq] = c.Data
+	//the chunk for next does not count against the window
+	if _, ok := r.pending[r.next]; !ok && len(r.pending) > r.window {
+		return fmt.Errorf("%w: chunk %d missing after %d later chunks", ErrSequenceGap, r.next, len(r.pending))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func feed(chunks ...SeqChunk) <-chan SeqChunk {
+	c := make(chan SeqChunk, len(chunks))
+	for _, ch := range chunks {
+		c <- ch
+	}
+	close(c)
+	return c
+}
+
+func TestReorderReader(t *testing.T) {
+	in := feed(
+		SeqChunk{2, []byte("c")},
+		SeqChunk{1, []byte("b")},
+		SeqChunk{0, []byte("a")},
+		SeqChunk{3, []byte("")},
+		SeqChunk{5, []byte("ef")},
+		SeqChunk{4, []byte("d")},
+	)
+	got, err := io.ReadAll(NewReorderReader(in, 2))
+	if err != nil || string(got) != "abcdef" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestReorderReaderConcurrent(t *testing.T) {
+	c := make(chan SeqChunk)
+	go func() {
+		defer close(c)
+		for _, seq := range []uint64{1, 0, 3, 2, 5, 4} {
+			c <- SeqChunk{seq, []byte{'a' + byte(seq)}}
+		}
+	}()
+	got, err := io.ReadAll(NewReorderReader(c, 1))
+	if err != nil || string(got) != "abcdef" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestReorderReaderGap(t *testing.T) {
+	tests := map[string]struct {
+		in   []SeqChunk
+		want error
+	}{
+		"window": {[]SeqChunk{{0, []byte("a")}, {2, nil}, {3, nil}, {4, nil}}, ErrSequenceGap},
+		"closed": {[]SeqChunk{{0, []byte("a")}, {2, nil}}, ErrSequenceGap},
+		"dup":    {[]SeqChunk{{0, []byte("a")}, {2, nil}, {2, nil}}, ErrDuplicateChunk},
+		"old":    {[]SeqChunk{{0, []byte("a")}, {0, nil}}, ErrDuplicateChunk},
+	}
+	for name, tt := range tests {
+		r := NewReorderReader(feed(tt.in...), 2)
+		got, err := io.ReadAll(r)
+		if string(got) != "a" || !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %q, %v want %v", name, got, err, tt.want)
+		}
+		if _, err2 := r.Read(make([]byte, 1)); err2 != err {
+			t.Errorf("%s: got %v after %v", name, err2, err)
+		}
+	}
+}
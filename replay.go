@@ -0,0 +1,139 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+//recordingMagic begins every file written by a RecordReader.
+var recordingMagic = []byte("simple recording v1\n")
+
+//ErrBadRecording is returned, possibly wrapped with more detail,
+//when replaying a file that was not written by a RecordReader.
+var ErrBadRecording = errors.New("simple: malformed recording")
+
+//RecordReader is a Reader that records everything it reads
+//to a file for later replay by NewReplayReader.
+type RecordReader struct {
+	*Reader
+	rr *recordReader
+}
+
+//NewRecordReader creates the file at path and returns a RecordReader
+//that reads from r and writes each read to the file.
+//
+//The recording preserves how the data was split between reads,
+//so that the replay reproduces the reads exactly.
+//
+//Writes to the file are buffered; Close must be called to flush them.
+func NewRecordReader(r io.Reader, path string) (*RecordReader, error) {
+	must(r)
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(f)
+	if _, err := w.Write(recordingMagic); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	rr := &recordReader{r: r, f: f, w: w}
+	return &RecordReader{
+		Reader: NewReader(rr),
+		rr:     rr,
+	}, nil
+}
+
+//Close flushes and closes the recording.
+//It does not close the wrapped io.Reader.
+func (r *RecordReader) Close() error {
+	return r.rr.close()
+}
+
+type recordReader struct {
+	r    io.Reader
+	f    *os.File
+	w    *bufio.Writer
+	werr error
+}
+
+func (rr *recordReader) Read(p []byte) (int, error) {
+	if rr.werr != nil {
+		return 0, rr.werr
+	}
+
+	n, err := rr.r.Read(p)
+	if n > 0 {
+		rr.w.Write(binary.AppendUvarint(nil, uint64(n)))
+		if _, werr := rr.w.Write(p[:n]); werr != nil {
+			rr.werr = fmt.Errorf("simple: writing recording: %w", werr)
+			if err == nil {
+				err = rr.werr
+			}
+		}
+	}
+	return n, err
+}
+
+func (rr *recordReader) close() error {
+	if rr.f == nil {
+		return nil
+	}
+	err := rr.w.Flush()
+	if cerr := rr.f.Close(); err == nil {
+		err = cerr
+	}
+	rr.f = nil
+	rr.werr = ErrClosed
+	return err
+}
+
+//NewReplayReader returns a Reader that replays the file at path,
+//as written by a RecordReader.
+//
+//Each Read returns no more than was returned by the corresponding
+//recorded read, so the same reads are reproduced
+//given buffers at least as large as the originals.
+func NewReplayReader(path string) (*Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := NewMagicReader(bytes.NewReader(data), recordingMagic); err != nil {
+		if errors.Is(err, ErrBadMagic) {
+			err = fmt.Errorf("%w: %s: %v", ErrBadRecording, path, err)
+		}
+		return nil, err
+	}
+	return NewReader(&replayReader{data: data[len(recordingMagic):]}), nil
+}
+
+type replayReader struct {
+	data []byte
+	left int //bytes left in the current recorded read
+}
+
+func (rr *replayReader) Read(p []byte) (int, error) {
+	if rr.left == 0 {
+		if len(rr.data) == 0 {
+			return 0, io.EOF
+		}
+		size, k := binary.Uvarint(rr.data)
+		if k <= 0 || size == 0 || size > uint64(len(rr.data)-k) {
+			return 0, ErrBadRecording
+		}
+		rr.data = rr.data[k:]
+		rr.left = int(size)
+	}
+
+	n := copy(p[:min(len(p), rr.left)], rr.data)
+	rr.data = rr.data[n:]
+	rr.left -= n
+	return n, nil
+}
@@ -0,0 +1,79 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec")
+	const in = "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"
+	sizes := []int{3, 1, 12, 20, 100}
+
+	rec, err := NewRecordReader(NewScriptedReader([]byte(in), sizes), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reads []string
+	p := make([]byte, 64)
+	for {
+		p, err := Read(rec, p)
+		if err != nil {
+			break
+		}
+		reads = append(reads, string(p))
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rep, err := NewReplayReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replays []string
+	for {
+		p, err := Read(rep, p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		replays = append(replays, string(p))
+	}
+
+	if fmt.Sprintf("%q", replays) != fmt.Sprintf("%q", reads) {
+		t.Errorf("recorded %q\nreplayed %q", reads, replays)
+	}
+	if strings.Join(replays, "") != in {
+		t.Errorf("got %q want %q", strings.Join(replays, ""), in)
+	}
+}
+
+func TestReplayReaderBad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec")
+	if err := os.WriteFile(path, []byte("not a recording"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReplayReader(path); !errors.Is(err, ErrBadRecording) {
+		t.Errorf("got %v want ErrBadRecording", err)
+	}
+
+	//a truncated chunk
+	if err := os.WriteFile(path, append(append([]byte(nil), recordingMagic...), 5, 'a'), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewReplayReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); !errors.Is(err, ErrBadRecording) {
+		t.Errorf("got %v want ErrBadRecording", err)
+	}
+}
@@ -0,0 +1,102 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+//ResilientOpts configures a Reader from NewResilientReader.
+//The zero value of each field selects its default.
+type ResilientOpts struct {
+	//Timeout, if positive, is the deadline set for each read
+	//when the wrapped io.Reader has a SetReadDeadline method,
+	//as net.Conn and *os.File do.
+	Timeout time.Duration
+
+	//MaxAttempts limits the reads made for each Read, including the first.
+	//The default is 3.
+	MaxAttempts int
+	//MaxDuration, if positive, limits the total time spent on a Read,
+	//including backoff.
+	//A retry that could not start before it runs out is not made.
+	MaxDuration time.Duration
+
+	//Backoff is the wait before the first retry,
+	//which doubles for each retry after, up to MaxBackoff.
+	//The defaults are 10ms and 1s.
+	Backoff, MaxBackoff time.Duration
+
+	//Transient reports whether a read that returned err may be retried.
+	//The default accepts timeouts:
+	//errors matching os.ErrDeadlineExceeded,
+	//or having a Timeout method that returns true, as net.Error does.
+	Transient func(err error) bool
+}
+
+//NewResilientReader returns a Reader that retries reads of r
+//that return no data and a transient error,
+//waiting with exponential backoff between attempts, as configured by opts.
+//
+//If the attempts run out, the error from the last one is returned.
+//A transient error returned with data is discarded,
+//so the next Read retries.
+func NewResilientReader(r io.Reader, opts ResilientOpts) *Reader {
+	must(r)
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 10 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Second
+	}
+	if opts.Transient == nil {
+		opts.Transient = isTimeout
+	}
+	return NewReader(&resilientReader{r: r, opts: opts})
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var t interface{ Timeout() bool }
+	return errors.As(err, &t) && t.Timeout()
+}
+
+type resilientReader struct {
+	r    io.Reader
+	opts ResilientOpts
+}
+
+func (rr *resilientReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	backoff := rr.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		if d, ok := rr.r.(interface{ SetReadDeadline(time.Time) error }); ok && rr.opts.Timeout > 0 {
+			if err := d.SetReadDeadline(time.Now().Add(rr.opts.Timeout)); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := rr.r.Read(p)
+		if err == nil || err == io.EOF || !rr.opts.Transient(err) {
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+
+		if attempt == rr.opts.MaxAttempts {
+			return 0, err
+		}
+		if limit := rr.opts.MaxDuration; limit > 0 && time.Since(start)+backoff >= limit {
+			return 0, err
+		}
+		time.Sleep(backoff)
+		backoff = min(2*backoff, rr.opts.MaxBackoff)
+	}
+}
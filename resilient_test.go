@@ -0,0 +1,92 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//flaky times out its first fails reads, recording when each read is made.
+type flaky struct {
+	r     io.Reader
+	fails int
+	times []time.Time
+	dl    []time.Time
+}
+
+func (f *flaky) Read(p []byte) (int, error) {
+	f.times = append(f.times, time.Now())
+	if f.fails > 0 {
+		f.fails--
+		return 0, os.ErrDeadlineExceeded
+	}
+	return f.r.Read(p)
+}
+
+func (f *flaky) SetReadDeadline(t time.Time) error {
+	f.dl = append(f.dl, t)
+	return nil
+}
+
+func TestResilientReader(t *testing.T) {
+	f := &flaky{r: strings.NewReader("hello"), fails: 2}
+	r := NewResilientReader(f, ResilientOpts{
+		Timeout: time.Second,
+		Backoff: 20 * time.Millisecond,
+	})
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "hello" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if len(f.times) < 3 {
+		t.Fatalf("%d reads", len(f.times))
+	}
+	if d := f.times[1].Sub(f.times[0]); d < 20*time.Millisecond {
+		t.Errorf("first backoff %v, want at least 20ms", d)
+	}
+	if d := f.times[2].Sub(f.times[1]); d < 40*time.Millisecond {
+		t.Errorf("second backoff %v, want at least 40ms", d)
+	}
+	if len(f.dl) != len(f.times) {
+		t.Errorf("set %d deadlines for %d reads", len(f.dl), len(f.times))
+	}
+}
+
+func TestResilientReaderExhausted(t *testing.T) {
+	f := &flaky{r: strings.NewReader("hello"), fails: 5}
+	r := NewResilientReader(f, ResilientOpts{MaxAttempts: 3, Backoff: time.Millisecond})
+	if _, err := r.Read(make([]byte, 5)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("got %v want os.ErrDeadlineExceeded", err)
+	}
+	if len(f.times) != 3 {
+		t.Errorf("%d reads, want 3", len(f.times))
+	}
+
+	//a time limit cuts the retries short
+	f = &flaky{r: strings.NewReader("hello"), fails: 5}
+	r = NewResilientReader(f, ResilientOpts{MaxAttempts: 10, Backoff: 20 * time.Millisecond, MaxDuration: 50 * time.Millisecond})
+	if _, err := r.Read(make([]byte, 5)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("got %v want os.ErrDeadlineExceeded", err)
+	}
+	if len(f.times) != 2 {
+		t.Errorf("%d reads, want 2", len(f.times))
+	}
+}
+
+func TestResilientReaderPermanent(t *testing.T) {
+	calls := 0
+	r := NewResilientReader(readFunc(func(p []byte) (int, error) {
+		calls++
+		return 0, errTruncated
+	}), ResilientOpts{Backoff: time.Millisecond})
+	if _, err := r.Read(make([]byte, 1)); err != errTruncated || calls != 1 {
+		t.Errorf("got %v after %d reads", err, calls)
+	}
+}
+
+type readFunc func([]byte) (int, error)
+
+func (f readFunc) Read(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,43 @@
+package simple
+
+import "io"
+
+//NewRLEReader returns a Reader that expands the run-length encoding read
+//from r, in which each pair of bytes is a count followed by
+//a value to repeat that many times.
+//A count of 0 is an empty run.
+//
+//Pairs may be split across reads of r,
+//and a run that does not fit in the buffer passed to Read
+//is continued by the next Read.
+//If r ends in the middle of a pair, the Reader returns io.ErrUnexpectedEOF
+//after the data before it.
+func NewRLEReader(r io.Reader) *Reader {
+	return newTransformReader(r, &rleTransform{})
+}
+
+type rleTransform struct {
+	odd   bool //whether count holds the first byte of a pair
+	count byte
+}
+
+func (t *rleTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		if !t.odd {
+			t.count = b
+		} else {
+			for i := 0; i < int(t.count); i++ {
+				out = append(out, b)
+			}
+		}
+		t.odd = !t.odd
+	}
+	return out, nil
+}
+
+func (t *rleTransform) flush(out []byte) ([]byte, error) {
+	if t.odd {
+		return out, io.ErrUnexpectedEOF
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRLEReader(t *testing.T) {
+	const in = "\x03a\x00z\x01b\xffc\x02d"
+	want := "aaab" + strings.Repeat("c", 255) + "dd"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		//small reads so runs overflow the caller's buffer
+		got, err := io.ReadAll(iotest.OneByteReader(NewRLEReader(wrap(strings.NewReader(in)))))
+		if err != nil || string(got) != want {
+			t.Errorf("got %q, %v", got, err)
+		}
+		got, err = io.ReadAll(NewRLEReader(wrap(strings.NewReader(in))))
+		if err != nil || string(got) != want {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+
+	//a pair split across reads
+	got, err := io.ReadAll(NewRLEReader(NewScriptedReader([]byte(in), []int{1, 2})))
+	if err != nil || string(got) != want {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestRLEReaderTruncated(t *testing.T) {
+	got, err := io.ReadAll(NewRLEReader(strings.NewReader("\x02a\x05")))
+	if err != io.ErrUnexpectedEOF || string(got) != "aa" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,88 @@
+package simple
+
+import "io"
+
+//RollingHashReader is a Reader that maintains a checksum
+//of the last bytes it delivered.
+type RollingHashReader struct {
+	*Reader
+	h *rollingHash
+}
+
+//NewRollingHashReader wraps r in a RollingHashReader
+//whose checksum covers the last windowSize bytes delivered.
+//
+//NewRollingHashReader panics if windowSize < 1.
+func NewRollingHashReader(r io.Reader, windowSize int) *RollingHashReader {
+	must(r)
+	if windowSize < 1 {
+		panic("window size must be positive")
+	}
+	h := newRollingHash(windowSize)
+	h.r = r
+	return &RollingHashReader{
+		Reader: NewReader(h),
+		h:      h,
+	}
+}
+
+//RollingSum returns the Adler-32 checksum of the last windowSize bytes
+//delivered, or of every byte delivered if there have been fewer.
+//
+//It equals adler32.Checksum of those bytes,
+//but is updated in constant time per byte as the window slides,
+//making it suitable for content defined chunking.
+func (r *RollingHashReader) RollingSum() uint32 {
+	return r.h.sum()
+}
+
+func newRollingHash(windowSize int) *rollingHash {
+	return &rollingHash{
+		window: make([]byte, windowSize),
+		a:      1,
+	}
+}
+
+//adlerMod is the modulus of Adler-32.
+const adlerMod = 65521
+
+type rollingHash struct {
+	r      io.Reader
+	window []byte //ring buffer of the last bytes delivered
+	next   int    //index in window of the oldest byte, once full
+	full   bool
+	a, b   uint32
+}
+
+func (h *rollingHash) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	for _, in := range p[:n] {
+		h.roll(in)
+	}
+	return n, err
+}
+
+//roll slides the window forward over in.
+func (h *rollingHash) roll(in byte) {
+	out := h.window[h.next]
+	h.window[h.next] = in
+	if h.next++; h.next == len(h.window) {
+		h.next = 0
+	}
+
+	if !h.full {
+		h.a = (h.a + uint32(in)) % adlerMod
+		h.b = (h.b + h.a) % adlerMod
+		h.full = h.next == 0
+		return
+	}
+
+	//drop out from the front of the window and add in at the back
+	size := uint32(len(h.window)) % adlerMod
+	h.a = (h.a + adlerMod - uint32(out) + uint32(in)) % adlerMod
+	h.b = (h.b + adlerMod - uint32(out)*size%adlerMod + h.a + adlerMod - 1) % adlerMod
+}
+
+func (h *rollingHash) sum() uint32 {
+	return h.b<<16 | h.a
+}
@@ -0,0 +1,40 @@
+package simple
+
+import (
+	"hash/adler32"
+	"math/rand"
+	"testing"
+)
+
+func TestRollingHashReader(t *testing.T) {
+	data := make([]byte, 5000)
+	rand.New(rand.NewSource(1)).Read(data)
+	//sums near the extreme of each byte
+	for i := 1000; i < 1100; i++ {
+		data[i] = 0xff
+	}
+
+	for _, window := range []int{1, 16, 100, 4096} {
+		r := NewRollingHashReader(NewScriptedReader(data, []int{1, 7, 33, 500}), window)
+		p := make([]byte, 64)
+		pos := 0
+		for {
+			n, err := r.Read(p)
+			if err != nil {
+				break
+			}
+			pos += n
+			want := adler32.Checksum(data[max(pos-window, 0):pos])
+			if got := r.RollingSum(); got != want {
+				t.Fatalf("window %d at %d: got %08x want %08x", window, pos, got, want)
+			}
+		}
+		if pos != len(data) {
+			t.Fatalf("read %d bytes", pos)
+		}
+	}
+
+	if r := NewRollingHashReader(NewScriptedReader(nil, []int{1}), 8); r.RollingSum() != adler32.Checksum(nil) {
+		t.Errorf("got %08x for no data", r.RollingSum())
+	}
+}
@@ -0,0 +1,68 @@
+package simple
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+//NewScannerReader returns a Reader that delivers the tokens of s,
+//one after another, as a continuous stream.
+//
+//Each Read delivers data from at most one token.
+//Once s stops, the Reader returns the error from s,
+//or io.EOF if there was none.
+//
+//NewScannerReader panics if s is nil.
+func NewScannerReader(s *bufio.Scanner) *Reader {
+	if s == nil {
+		panic("cannot wrap nil *bufio.Scanner")
+	}
+	return NewReader(&scannerReader{s: s})
+}
+
+type scannerReader struct {
+	s   *bufio.Scanner
+	tok []byte //undelivered part of the current token
+	err error
+}
+
+func (s *scannerReader) Read(p []byte) (int, error) {
+	for len(s.tok) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if !s.s.Scan() {
+			s.err = s.s.Err()
+			if s.err == nil {
+				s.err = io.EOF
+			}
+			continue
+		}
+		s.tok = s.s.Bytes()
+	}
+	n := copy(p, s.tok)
+	s.tok = s.tok[n:]
+	return n, nil
+}
+
+//Tokens returns an iterator over the tokens of s.
+//
+//Each token is yielded with a nil error and,
+//as with s.Bytes, is only valid until the next iteration.
+//The last pair yielded has a nil token and either io.EOF,
+//if s stopped cleanly, or the error that stopped it.
+func Tokens(s *bufio.Scanner) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for s.Scan() {
+			if !yield(s.Bytes(), nil) {
+				return
+			}
+		}
+		err := s.Err()
+		if err == nil {
+			err = io.EOF
+		}
+		yield(nil, err)
+	}
+}
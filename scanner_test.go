@@ -0,0 +1,61 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//splitCommas is a bufio.SplitFunc for comma separated fields.
+func splitCommas(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, ','); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func TestScannerReader(t *testing.T) {
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		s := bufio.NewScanner(wrap(strings.NewReader("ab,,cde,f")))
+		s.Split(splitCommas)
+		got, err := io.ReadAll(iotest.OneByteReader(NewScannerReader(s)))
+		if err != nil || string(got) != "abcdef" {
+			t.Errorf("got %q, %v", got, err)
+		}
+	}
+}
+
+func TestScannerReaderError(t *testing.T) {
+	s := bufio.NewScanner(strings.NewReader("a,bbbbbbbbbb"))
+	s.Split(splitCommas)
+	s.Buffer(nil, 4)
+	got, err := io.ReadAll(NewScannerReader(s))
+	if !errors.Is(err, bufio.ErrTooLong) || string(got) != "a" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	s := bufio.NewScanner(strings.NewReader("ab,,cde,f"))
+	s.Split(splitCommas)
+	var got []string
+	for tok, err := range Tokens(s) {
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		got = append(got, string(tok))
+	}
+	if s := strings.Join(got, "|"); s != "ab||cde|f" {
+		t.Errorf("got %q", s)
+	}
+}
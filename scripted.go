@@ -0,0 +1,55 @@
+package simple
+
+import "io"
+
+//NewScriptedReader returns a Reader over data whose successive reads
+//return chunks of exactly the sizes given, in order,
+//cycling back to the first size once they are exhausted.
+//
+//This allows tests to force a particular fragmentation of a stream.
+//
+//If the buffer passed to Read is smaller than the current size,
+//the remainder of that chunk is returned by the next Read,
+//before moving on to the next size,
+//so the chunk boundaries within data are the same regardless of buffer size.
+//A size of 0 makes a Read return 0, nil.
+//The last chunk is cut short if data runs out.
+//
+//NewScriptedReader panics if sizes is empty or contains a negative size.
+func NewScriptedReader(data []byte, sizes []int) *Reader {
+	if len(sizes) == 0 {
+		panic("no sizes in script")
+	}
+	for _, n := range sizes {
+		if n < 0 {
+			panic("negative size in script")
+		}
+	}
+	return NewReader(&scriptedReader{
+		data:  data,
+		sizes: append([]int(nil), sizes...),
+		left:  sizes[0],
+	})
+}
+
+type scriptedReader struct {
+	data  []byte
+	sizes []int
+	i     int //index of the current size
+	left  int //bytes left in the current chunk
+}
+
+func (s *scriptedReader) Read(p []byte) (int, error) {
+	if len(s.data) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p[:min(len(p), s.left)], s.data)
+	s.data = s.data[n:]
+	s.left -= n
+	if s.left == 0 {
+		s.i = (s.i + 1) % len(s.sizes)
+		s.left = s.sizes[s.i]
+	}
+	return n, nil
+}
@@ -0,0 +1,60 @@
+package simple
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func ExampleNewScriptedReader() {
+	//split a CRLF across two reads
+	r := NewScriptedReader([]byte("line one\r\nline two\r\n"), []int{9, 1, 100})
+	p := make([]byte, 64)
+	for {
+		p, err := Read(r, p)
+		if err != nil {
+			break
+		}
+		fmt.Printf("%q\n", p)
+	}
+	// Output:
+	// "line one\r"
+	// "\n"
+	// "line two\r\n"
+}
+
+func ExampleNewScriptedReader_cycle() {
+	//sizes repeat once exhausted
+	r := NewScriptedReader([]byte("abcdefghij"), []int{1, 2})
+	p := make([]byte, 64)
+	for {
+		p, err := Read(r, p)
+		if err != nil {
+			break
+		}
+		fmt.Printf("%s ", p)
+	}
+	fmt.Println()
+	// Output:
+	// a bc d ef g hi j
+}
+
+func TestScriptedReaderSmallBuffer(t *testing.T) {
+	//a chunk larger than the buffer is finished before the next size
+	r := NewScriptedReader([]byte("abcdefg"), []int{5, 0, 2})
+	p := make([]byte, 2)
+	var got []string
+	for {
+		p, err := Read(r, p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(p))
+	}
+	if s := fmt.Sprint(got); s != "[ab cd e  fg]" {
+		t.Errorf("got %s", s)
+	}
+}
@@ -0,0 +1,164 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//NewSentinelReader returns a Reader that passes r through unchanged
+//but calls onFound each time the byte sequence sentinel is read.
+//
+//Occurrences of sentinel do not overlap and may span any number of reads
+//of r.
+//Only the last len(sentinel)-1 bytes read are retained for matching.
+//
+//onFound may be nil.
+//
+//NewSentinelReader panics if sentinel is empty.
+func NewSentinelReader(r io.Reader, sentinel []byte, onFound func()) *Reader {
+	return NewReader(newSentinel(r, sentinel, onFound, false))
+}
+
+//NewSentinelStopReader is like NewSentinelReader except that the
+//returned Reader stops at the first sentinel:
+//it delivers every byte before the sentinel, calls onFound,
+//then returns io.EOF without delivering the sentinel.
+//
+//Any bytes read from r past the sentinel are discarded.
+func NewSentinelStopReader(r io.Reader, sentinel []byte, onFound func()) *Reader {
+	return NewReader(newSentinel(r, sentinel, onFound, true))
+}
+
+func newSentinel(r io.Reader, sentinel []byte, onFound func(), stop bool) *sentinelReader {
+	must(r)
+	if len(sentinel) == 0 {
+		panic("sentinel cannot be empty")
+	}
+	if onFound == nil {
+		onFound = func() {}
+	}
+	return &sentinelReader{
+		r:        r,
+		sentinel: append([]byte(nil), sentinel...),
+		onFound:  onFound,
+		stop:     stop,
+	}
+}
+
+type sentinelReader struct {
+	r        io.Reader
+	sentinel []byte
+	onFound  func()
+	stop     bool
+
+	//notify mode: the last len(sentinel)-1 bytes delivered,
+	//the first skip of which belong to an already reported sentinel,
+	//and scratch space for matching across the read boundary.
+	tail, win []byte
+	skip      int
+
+	//stop mode: bytes read from r but not yet delivered
+	//and the error that came with the last of them.
+	mem, buf []byte
+	err      error
+	found    bool
+}
+
+func (s *sentinelReader) Read(p []byte) (int, error) {
+	if s.stop {
+		return s.readStop(p)
+	}
+
+	n, err := s.r.Read(p)
+	if n > 0 {
+		s.scan(p[:n])
+	}
+	return n, err
+}
+
+//scan looks for sentinels ending in b, which was just delivered.
+func (s *sentinelReader) scan(b []byte) {
+	//positions are relative to the start of tail followed by b
+	k := len(s.sentinel) - 1
+	last := s.skip
+
+	//a sentinel cannot fit in tail or in the first k bytes of b,
+	//so search across the boundary first, then the rest of b in place
+	s.win = append(append(s.win[:0], s.tail...), b[:min(k, len(b))]...)
+	last = s.find(s.win, last)
+	from := max(last-len(s.tail), 0)
+	if end := s.find(b, from); end != from {
+		last = len(s.tail) + end
+	}
+
+	//keep the last k bytes for matching sentinels split across reads
+	start := max(len(s.tail)+len(b)-k, 0)
+	if start >= len(s.tail) {
+		s.tail = append(s.tail[:0], b[start-len(s.tail):]...)
+	} else {
+		//b is shorter than k so s.win holds all of tail and b
+		s.tail = append(s.tail[:0], s.win[start:]...)
+	}
+	s.skip = max(last-start, 0)
+}
+
+//find reports each sentinel in b at or after from
+//and returns the position after the last one, or from if there were none.
+func (s *sentinelReader) find(b []byte, from int) int {
+	for {
+		i := bytes.Index(b[from:], s.sentinel)
+		if i < 0 {
+			return from
+		}
+		from += i + len(s.sentinel)
+		s.onFound()
+	}
+}
+
+func (s *sentinelReader) readStop(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		if !s.found {
+			if i := bytes.Index(s.buf, s.sentinel); i >= 0 {
+				s.found = true
+				s.buf = s.buf[:i]
+				s.onFound()
+			}
+		}
+
+		//unless we know the stream is done, hold back enough bytes
+		//that the sentinel could still be completed by the next read
+		safe := len(s.buf)
+		if !s.found && s.err == nil {
+			safe -= len(s.sentinel) - 1
+		}
+		if safe > 0 {
+			n := copy(p, s.buf[:safe])
+			s.buf = s.buf[n:]
+			return n, nil
+		}
+
+		switch {
+		case s.found:
+			return 0, io.EOF
+		case s.err != nil:
+			return 0, s.err
+		}
+
+		need := len(s.buf) + len(p)
+		if cap(s.mem) < need {
+			s.mem = make([]byte, need)
+		}
+		s.buf = s.mem[:copy(s.mem[:cap(s.mem)], s.buf)]
+
+		n, err := s.r.Read(s.mem[len(s.buf):need])
+		s.buf = s.mem[:len(s.buf)+n]
+		s.err = err
+		if n == 0 && err == nil {
+			return 0, nil
+		}
+	}
+}
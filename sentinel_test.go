@@ -0,0 +1,93 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSentinelReader(t *testing.T) {
+	const in = "--b--xx--b----b"
+	wraps := map[string]func(io.Reader) io.Reader{
+		"whole":   func(r io.Reader) io.Reader { return r },
+		"onebyte": iotest.OneByteReader,
+		"half":    iotest.HalfReader,
+		"dataerr": iotest.DataErrReader,
+	}
+	for name, wrap := range wraps {
+		found := 0
+		r := NewSentinelReader(wrap(strings.NewReader(in)), []byte("--b"), func() { found++ })
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(got) != in {
+			t.Errorf("%s: got %q want %q", name, got, in)
+		}
+		if found != 3 {
+			t.Errorf("%s: found %d sentinels, want 3", name, found)
+		}
+	}
+}
+
+func TestSentinelReaderOverlap(t *testing.T) {
+	found := 0
+	r := NewSentinelReader(iotest.OneByteReader(strings.NewReader("aaaaa")), []byte("aa"), func() { found++ })
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	if found != 2 {
+		t.Errorf("found %d sentinels, want 2", found)
+	}
+}
+
+func TestSentinelStopReader(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"header\r\n\r\nbody", "header"},
+		{"\r\n\r\nbody", ""},
+		{"no sentinel\r\n\r", "no sentinel\r\n\r"},
+		{"hea\r\nder\r\n\r\n", "hea\r\nder"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			found := false
+			r := NewSentinelStopReader(wrap(strings.NewReader(c.in)), []byte("\r\n\r\n"), func() { found = true })
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("%q: %v", c.in, err)
+			}
+			if string(got) != c.want {
+				t.Errorf("%q: got %q want %q", c.in, got, c.want)
+			}
+			if want := c.want != c.in; found != want {
+				t.Errorf("%q: found = %v, want %v", c.in, found, want)
+			}
+			if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+				t.Errorf("%q: read after stop returned %d, %v", c.in, n, err)
+			}
+		}
+	}
+}
+
+func TestSentinelReaderCount(t *testing.T) {
+	in := strings.Repeat("abcab-abab-aab-", 20)
+	for _, sentinel := range []string{"a", "ab", "aba", "abab", "ab-a", "-aab-abcab"} {
+		want := strings.Count(in, sentinel)
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			found := 0
+			r := NewSentinelReader(wrap(strings.NewReader(in)), []byte(sentinel), func() { found++ })
+			if _, err := io.ReadAll(r); err != nil {
+				t.Fatal(err)
+			}
+			if found != want {
+				t.Errorf("%q: found %d, want %d", sentinel, found, want)
+			}
+		}
+	}
+
+	//a nil onFound is allowed
+	if _, err := io.ReadAll(NewSentinelReader(strings.NewReader(in), []byte("ab"), nil)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,67 @@
+package simple
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+//SlowReader is a Reader that waits before each read
+//of the wrapped io.Reader.
+type SlowReader struct {
+	*Reader
+	s *slowReader
+}
+
+//NewSlowReader wraps r in a SlowReader that sleeps for perRead
+//before each call to r.Read, to simulate slow storage.
+//
+//Returning an error stored from a previous read does not call r.Read,
+//so it does not sleep.
+func NewSlowReader(r io.Reader, perRead time.Duration) *SlowReader {
+	must(r)
+	s := &slowReader{
+		r:       r,
+		perRead: perRead,
+		closed:  make(chan struct{}),
+	}
+	return &SlowReader{
+		Reader: NewReader(s),
+		s:      s,
+	}
+}
+
+//Close stops the reader.
+//Any Read that is sleeping returns ErrClosed immediately,
+//as do all later Reads.
+//If the wrapped io.Reader is an io.Closer, it is closed.
+//
+//Close may be called concurrently with Read.
+func (s *SlowReader) Close() error {
+	var err error
+	s.s.once.Do(func() {
+		close(s.s.closed)
+		if c, ok := s.s.r.(io.Closer); ok {
+			err = c.Close()
+		}
+	})
+	return err
+}
+
+type slowReader struct {
+	r       io.Reader
+	perRead time.Duration
+	once    sync.Once
+	closed  chan struct{}
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	t := time.NewTimer(s.perRead)
+	select {
+	case <-s.closed:
+		t.Stop()
+		return 0, ErrClosed
+	case <-t.C:
+	}
+	return s.r.Read(p)
+}
@@ -0,0 +1,51 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestSlowReader(t *testing.T) {
+	const d = 5 * time.Millisecond
+	r := NewSlowReader(iotest.OneByteReader(strings.NewReader("abcd")), d)
+
+	start := time.Now()
+	got, err := io.ReadAll(r)
+	elapsed := time.Since(start)
+	if err != nil || string(got) != "abcd" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	//four reads with data and one returning io.EOF
+	if want := 5 * d; elapsed < want {
+		t.Errorf("took %v, want at least %v", elapsed, want)
+	}
+}
+
+func TestSlowReaderDeferred(t *testing.T) {
+	const d = 50 * time.Millisecond
+	r := NewSlowReader(&dataErr{"abc", errTruncated}, d)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := r.Read(make([]byte, 10)); err != errTruncated {
+		t.Fatalf("got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= d {
+		t.Errorf("returning the stored error slept for %v", elapsed)
+	}
+}
+
+func TestSlowReaderClose(t *testing.T) {
+	r := NewSlowReader(strings.NewReader("abc"), time.Hour)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		r.Close()
+	}()
+	if _, err := r.Read(make([]byte, 1)); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
@@ -0,0 +1,154 @@
+package simple
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+//SpillReader is a Reader that keeps everything it reads
+//so that it can be read again from the start.
+type SpillReader struct {
+	*Reader
+	s *spillReader
+}
+
+//NewSpillReader wraps r in a SpillReader that keeps the first inMemLimit
+//bytes read from r in memory and the rest in a temporary file,
+//created by os.CreateTemp in the default directory when first needed.
+//
+//This allows multiple passes over a stream that cannot seek,
+//without unbounded memory use.
+//The temporary file is removed by Close.
+//
+//NewSpillReader panics if inMemLimit is negative.
+func NewSpillReader(r io.Reader, inMemLimit int) *SpillReader {
+	must(r)
+	if inMemLimit < 0 {
+		panic("in memory limit cannot be negative")
+	}
+	s := &spillReader{
+		r:     r,
+		limit: inMemLimit,
+	}
+	return &SpillReader{
+		Reader: NewReader(s),
+		s:      s,
+	}
+}
+
+//Rewind returns to the start of the stream,
+//so that the next Read delivers the first byte read from r.
+//Reads replay the kept bytes before reading more of r.
+//
+//Rewind discards any error stored by the Reader.
+func (s *SpillReader) Rewind() error {
+	if s.s.closed {
+		return ErrClosed
+	}
+	s.Reader.SetError(nil)
+	s.Reader.done = false
+	s.s.pos = 0
+	return nil
+}
+
+//Close removes the temporary file, if any,
+//and closes the wrapped io.Reader if it is an io.Closer.
+//All later Reads return ErrClosed.
+func (s *SpillReader) Close() error {
+	return s.s.close()
+}
+
+type spillReader struct {
+	r     io.Reader
+	limit int
+
+	mem  []byte   //the first bytes read from r, up to limit
+	file *os.File //the rest
+	size int64    //total bytes read from r
+	pos  int64    //offset of the next byte delivered
+	eof  bool     //whether r has returned io.EOF
+
+	once   sync.Once
+	closed bool
+}
+
+func (s *spillReader) Read(p []byte) (int, error) {
+	if s.closed {
+		return 0, ErrClosed
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	//replay kept bytes
+	if s.pos < s.size {
+		if s.pos < int64(len(s.mem)) {
+			n := copy(p, s.mem[s.pos:])
+			s.pos += int64(n)
+			return n, nil
+		}
+		p = p[:min(int64(len(p)), s.size-s.pos)]
+		n, err := s.file.ReadAt(p, s.pos-int64(len(s.mem)))
+		s.pos += int64(n)
+		if n > 0 {
+			return n, nil
+		}
+		return 0, noEOF(err)
+	}
+	if s.eof {
+		return 0, io.EOF
+	}
+
+	n, err := s.r.Read(p)
+	if n > 0 {
+		if kerr := s.keep(p[:n]); kerr != nil {
+			return 0, kerr
+		}
+		s.pos += int64(n)
+	}
+	if err == io.EOF {
+		s.eof = true
+	}
+	return n, err
+}
+
+//keep stores b, just read from r, after everything else kept.
+func (s *spillReader) keep(b []byte) error {
+	k := min(len(b), s.limit-len(s.mem))
+	s.mem = append(s.mem, b[:k]...)
+	if k < len(b) {
+		if s.file == nil {
+			f, err := os.CreateTemp("", "simple-spill-*")
+			if err != nil {
+				return err
+			}
+			s.file = f
+		}
+		if _, err := s.file.WriteAt(b[k:], s.size+int64(k)-int64(len(s.mem))); err != nil {
+			return err
+		}
+	}
+	s.size += int64(len(b))
+	return nil
+}
+
+func (s *spillReader) close() error {
+	var err error
+	s.once.Do(func() {
+		s.closed = true
+		s.mem = nil
+		if s.file != nil {
+			err = s.file.Close()
+			if rerr := os.Remove(s.file.Name()); err == nil {
+				err = rerr
+			}
+		}
+		if c, ok := s.r.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
@@ -0,0 +1,102 @@
+package simple
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+	"testing/iotest"
+)
+
+func testSpill(t *testing.T, data []byte, limit int) *SpillReader {
+	t.Helper()
+	s := NewSpillReader(iotest.HalfReader(bytes.NewReader(data)), limit)
+	t.Cleanup(func() { s.Close() })
+
+	//a partial pass, then two full passes
+	if _, err := io.ReadFull(s, make([]byte, len(data)/3)); err != nil {
+		t.Fatal(err)
+	}
+	for pass := 0; pass < 2; pass++ {
+		if err := s.Rewind(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(iotest.OneByteReader(s))
+		if err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("pass %d: got %d bytes, %v", pass, len(got), err)
+		}
+	}
+	return s
+}
+
+func TestSpillReaderInMemory(t *testing.T) {
+	data := []byte("Hello, World! Hello, World!")
+	s := testSpill(t, data, len(data))
+	if s.s.file != nil {
+		t.Error("spilled to disk")
+	}
+}
+
+func TestSpillReaderSpill(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+	s := testSpill(t, data, 100)
+	if s.s.file == nil {
+		t.Fatal("did not spill to disk")
+	}
+	if len(s.s.mem) != 100 {
+		t.Errorf("kept %d bytes in memory", len(s.s.mem))
+	}
+
+	name := s.s.file.Name()
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("temporary file not removed: %v", err)
+	}
+	if _, err := s.Read(make([]byte, 1)); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+	if err := s.Rewind(); err != ErrClosed {
+		t.Errorf("got %v want ErrClosed", err)
+	}
+}
+
+func TestSpillReaderRewindError(t *testing.T) {
+	//an error returned with the data is replaced by the replay on Rewind
+	s := NewSpillReader(&dataErr{"abc", errTruncated}, 0)
+	defer s.Close()
+	if p, err := Read(s, make([]byte, 10)); string(p) != "abc" || err != nil {
+		t.Fatalf("got %q, %v", p, err)
+	}
+	s.Rewind()
+	got, err := io.ReadAll(s)
+	if string(got) != "abc" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if !s.Done() {
+		t.Error("not done")
+	}
+	s.Rewind()
+	if s.Done() {
+		t.Error("done after Rewind")
+	}
+}
+
+func TestSpillReaderClose(t *testing.T) {
+	c := &closeCounter{Reader: bytes.NewReader(nil)}
+	s := NewSpillReader(c, 10)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if c.closes != 1 {
+		t.Errorf("closed %d times", c.closes)
+	}
+	if _, err := s.Read(make([]byte, 1)); !errors.Is(err, ErrClosed) {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package simple
+
+import "io"
+
+//SpliceAt inserts the entire contents of ins into the stream
+//once off bytes have been read.
+//
+//The bytes before off are delivered, then ins until it returns io.EOF,
+//then the remainder of the stream.
+//If the stream holds exactly off bytes, ins is appended to it.
+//If the stream ends before off, ins is never read.
+//
+//Options apply in the order given, so the off of a SpliceAt after
+//another SpliceAt counts any bytes inserted by the first.
+func SpliceAt(off int64, ins io.Reader) Option {
+	must(ins)
+	if off < 0 {
+		panic("negative splice offset")
+	}
+	return func(r *Reader) {
+		r.r = &spliceReader{r: r.r, ins: ins, off: off}
+	}
+}
+
+type spliceReader struct {
+	r, ins   io.Reader
+	off, pos int64
+	inserted bool //whether all of ins has been read
+	eof      bool //whether r returned io.EOF at off
+}
+
+func (s *spliceReader) Read(p []byte) (int, error) {
+	if s.pos < s.off {
+		if rem := s.off - s.pos; int64(len(p)) > rem {
+			p = p[:rem]
+		}
+		n, err := s.r.Read(p)
+		s.pos += int64(n)
+		if err == io.EOF && s.pos == s.off {
+			//hold the EOF until after ins
+			s.eof = true
+			err = nil
+		}
+		return n, err
+	}
+
+	if !s.inserted {
+		n, err := s.ins.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+		s.inserted = true
+		if n > 0 {
+			return n, nil
+		}
+	}
+
+	if s.eof {
+		return 0, io.EOF
+	}
+	return s.r.Read(p)
+}
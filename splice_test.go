@@ -0,0 +1,49 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSpliceAt(t *testing.T) {
+	cases := []struct {
+		off  int64
+		want string
+	}{
+		{0, "[ins]Hello, World!"},
+		{5, "Hello[ins], World!"},
+		{13, "Hello, World![ins]"},
+		{14, "Hello, World!"},
+	}
+	for _, c := range cases {
+		b := NewBasic("Hello, World!")
+		got, err := io.ReadAll(NewReader(&b, SpliceAt(c.off, strings.NewReader("[ins]"))))
+		if err != nil || string(got) != c.want {
+			t.Errorf("%d: got %q, %v want %q", c.off, got, err, c.want)
+		}
+
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			ins := wrap(strings.NewReader("[ins]"))
+			got, err := io.ReadAll(NewReader(wrap(strings.NewReader("Hello, World!")), SpliceAt(c.off, ins)))
+			if err != nil {
+				t.Fatalf("%d: %v", c.off, err)
+			}
+			if string(got) != c.want {
+				t.Errorf("%d: got %q want %q", c.off, got, c.want)
+			}
+		}
+	}
+}
+
+func TestSpliceAtTwice(t *testing.T) {
+	r := NewReader(strings.NewReader("ac"),
+		SpliceAt(1, strings.NewReader("b")),
+		SpliceAt(3, strings.NewReader("d")),
+	)
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "abcd" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
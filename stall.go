@@ -0,0 +1,49 @@
+package simple
+
+import (
+	"errors"
+	"io"
+)
+
+//ErrStalled is returned by a Reader from NewStallDetectorReader
+//when the wrapped io.Reader repeatedly fails to make progress.
+var ErrStalled = errors.New("simple: reader stalled")
+
+//NewStallDetectorReader returns a Reader that counts consecutive reads of r
+//that return 0, nil and returns ErrStalled instead of the maxStalls-th.
+//
+//Only reads with a non-empty buffer count, since a read into an empty buffer
+//cannot make progress, and they neither count nor reset the count.
+//Any other read returning data or an error resets the count.
+//
+//NewStallDetectorReader panics if maxStalls < 1.
+func NewStallDetectorReader(r io.Reader, maxStalls int) *Reader {
+	must(r)
+	if maxStalls < 1 {
+		panic("max stalls must be positive")
+	}
+	return NewReader(&stallReader{r: r, max: maxStalls})
+}
+
+type stallReader struct {
+	r           io.Reader
+	stalls, max int
+}
+
+func (s *stallReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if len(p) == 0 {
+		return n, err
+	}
+	if n > 0 || err != nil {
+		s.stalls = 0
+		return n, err
+	}
+
+	s.stalls++
+	if s.stalls >= s.max {
+		s.stalls = 0
+		return 0, ErrStalled
+	}
+	return 0, nil
+}
@@ -0,0 +1,82 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+//staller returns 0, nil stalls times before each read of r.
+type staller struct {
+	r      io.Reader
+	stalls int
+	left   int
+}
+
+func (s *staller) Read(p []byte) (int, error) {
+	if s.left > 0 {
+		s.left--
+		return 0, nil
+	}
+	s.left = s.stalls
+	return s.r.Read(p)
+}
+
+func TestStallDetectorReaderRecovers(t *testing.T) {
+	r := NewStallDetectorReader(&staller{r: strings.NewReader("abc"), stalls: 2, left: 2}, 3)
+	var got []byte
+	p := make([]byte, 1)
+	for {
+		n, err := r.Read(p)
+		got = append(got, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if string(got) != "abc" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestStallDetectorReaderStalls(t *testing.T) {
+	r := NewStallDetectorReader(&staller{r: strings.NewReader("abc"), stalls: 3, left: 3}, 3)
+	p := make([]byte, 1)
+	for i := 0; i < 2; i++ {
+		if n, err := r.Read(p); n != 0 || err != nil {
+			t.Fatalf("stall %d: got %d, %v", i, n, err)
+		}
+	}
+	if _, err := r.Read(p); err != ErrStalled {
+		t.Errorf("got %v want ErrStalled", err)
+	}
+}
+
+func TestStallDetectorReaderEmptyBuffer(t *testing.T) {
+	b := NewBasic("abc")
+	r := NewStallDetectorReader(&b, 1)
+	for i := 0; i < 5; i++ {
+		if n, err := r.Read(nil); n != 0 || err != nil {
+			t.Fatalf("got %d, %v", n, err)
+		}
+	}
+}
+
+func TestStallDetectorReaderEmptyBetweenStalls(t *testing.T) {
+	r := NewStallDetectorReader(&staller{r: strings.NewReader("abc"), stalls: 3, left: 3}, 3)
+	p := make([]byte, 1)
+	for i := 0; i < 2; i++ {
+		if n, err := r.Read(p); n != 0 || err != nil {
+			t.Fatalf("stall %d: got %d, %v", i, n, err)
+		}
+		//an empty read does not hide the stall
+		if n, err := r.Read(nil); n != 0 || err != nil {
+			t.Fatalf("empty read %d: got %d, %v", i, n, err)
+		}
+	}
+	if _, err := r.Read(p); err != ErrStalled {
+		t.Errorf("got %v want ErrStalled", err)
+	}
+}
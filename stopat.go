@@ -0,0 +1,139 @@
+package simple
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+//StopAtReader is a Reader that ends at a stop sequence,
+//leaving it and everything after it unread.
+type StopAtReader struct {
+	*Reader
+	s *stopAtReader
+}
+
+//NewStopAtReader wraps r in a StopAtReader that delivers the bytes of r
+//up to the first occurrence of stop,
+//then returns io.EOF without consuming stop.
+//
+//r is read through a *bufio.Reader, used as is if r is one large enough,
+//so that the stop sequence can be recognized before it is consumed.
+//Once the StopAtReader returns io.EOF, Rest returns a reader
+//positioned at the start of stop, so that another parser can take over.
+//If r ends without stop, the StopAtReader returns io.EOF at the end of r.
+//
+//NewStopAtReader panics if stop is empty.
+func NewStopAtReader(r io.Reader, stop []byte) *StopAtReader {
+	must(r)
+	if len(stop) == 0 {
+		panic("stop cannot be empty")
+	}
+	br, ok := r.(*bufio.Reader)
+	if !ok || br.Size() < len(stop) {
+		br = bufio.NewReaderSize(r, max(4096, len(stop)))
+	}
+	s := newStopAt(br, stop, false)
+	return &StopAtReader{
+		Reader: NewReader(s),
+		s:      s,
+	}
+}
+
+//Rest returns the underlying *bufio.Reader,
+//holding everything that has not been delivered:
+//after the StopAtReader returns io.EOF, it starts with the stop sequence.
+func (s *StopAtReader) Rest() *bufio.Reader {
+	return s.s.br
+}
+
+//NewMarkerTerminatedReader returns a Reader that delivers the bytes of r
+//up to the first occurrence of marker,
+//then consumes marker and returns io.EOF.
+//If r ends without marker, the Reader returns io.EOF at the end of r,
+//after delivering any part of marker r ended with.
+//
+//Nothing past marker is read from r, so r is left positioned just after it.
+//If r is a *bufio.Reader that can hold marker, it is read directly,
+//and anything after marker remains in its buffer.
+//Otherwise, r is read one byte at a time,
+//so wrapping r in a *bufio.Reader is much faster.
+//
+//NewMarkerTerminatedReader panics if marker is empty.
+func NewMarkerTerminatedReader(r io.Reader, marker []byte) *Reader {
+	must(r)
+	if len(marker) == 0 {
+		panic("marker cannot be empty")
+	}
+	br, ok := r.(*bufio.Reader)
+	if !ok || br.Size() < len(marker) {
+		br = bufio.NewReaderSize(&oneByteReader{r}, len(marker))
+	}
+	return NewReader(newStopAt(br, marker, true))
+}
+
+//oneByteReader reads at most one byte at a time from r,
+//so that a *bufio.Reader reads no further ahead than it must to Peek.
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (o *oneByteReader) Read(p []byte) (int, error) {
+	return o.r.Read(p[:min(len(p), 1)])
+}
+
+type stopAtReader struct {
+	br      *bufio.Reader
+	stop    []byte
+	consume bool //whether to discard the stop sequence when it is found
+	done    bool
+}
+
+func newStopAt(br *bufio.Reader, stop []byte, consume bool) *stopAtReader {
+	return &stopAtReader{
+		br:      br,
+		stop:    append([]byte(nil), stop...),
+		consume: consume,
+	}
+}
+
+func (s *stopAtReader) Read(p []byte) (int, error) {
+	if s.done {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	data, err := s.br.Peek(max(s.br.Buffered(), 1))
+	for {
+		i := bytes.Index(data, s.stop)
+		if i == 0 {
+			s.done = true
+			if s.consume {
+				s.br.Discard(len(s.stop))
+			}
+			return 0, io.EOF
+		}
+
+		//deliver the bytes that cannot begin the stop sequence
+		safe := i
+		if i < 0 {
+			safe = len(data) - len(s.stop) + 1
+			if err != nil {
+				safe = len(data)
+			}
+		}
+		if safe > 0 {
+			n := copy(p, data[:safe])
+			s.br.Discard(n)
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		//not enough is buffered to tell whether it begins the stop sequence
+		data, err = s.br.Peek(len(data) + 1)
+	}
+}
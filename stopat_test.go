@@ -0,0 +1,95 @@
+package simple
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStopAtReader(t *testing.T) {
+	const in = "preamble --b-- more --boundary\r\nrest"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewStopAtReader(wrap(strings.NewReader(in)), []byte("--boundary"))
+		got, err := io.ReadAll(iotest.OneByteReader(r))
+		if err != nil || string(got) != "preamble --b-- more " {
+			t.Errorf("got %q, %v", got, err)
+		}
+		if n, err := r.Read(make([]byte, 1)); n != 0 || err != io.EOF {
+			t.Errorf("read after stop: %d, %v", n, err)
+		}
+		if rest, err := io.ReadAll(r.Rest()); err != nil || string(rest) != "--boundary\r\nrest" {
+			t.Errorf("rest: got %q, %v", rest, err)
+		}
+	}
+
+	//the stop sequence split across every pair of reads
+	stop := []byte("--boundary")
+	for i := 0; i <= len(in); i++ {
+		r := NewStopAtReader(NewScriptedReader([]byte(in), []int{i, 3, len(in)}), stop)
+		if got, _ := io.ReadAll(r); string(got) != "preamble --b-- more " {
+			t.Errorf("split at %d: got %q", i, got)
+		}
+	}
+}
+
+func TestStopAtReaderNoStop(t *testing.T) {
+	for _, in := range []string{"no stop here", "ends with partial --bound", ""} {
+		r := NewStopAtReader(iotest.HalfReader(strings.NewReader(in)), []byte("--boundary"))
+		if got, err := io.ReadAll(r); err != nil || string(got) != in {
+			t.Errorf("%q: got %q, %v", in, got, err)
+		}
+	}
+}
+
+func TestStopAtReaderBufio(t *testing.T) {
+	//an existing *bufio.Reader is used, so nothing is lost to a second buffer
+	br := bufio.NewReader(strings.NewReader("header\n\nbody"))
+	r := NewStopAtReader(br, []byte("\n\n"))
+	if r.Rest() != br {
+		t.Fatal("did not use the *bufio.Reader")
+	}
+	if got, _ := io.ReadAll(r); string(got) != "header" {
+		t.Errorf("got %q", got)
+	}
+	if rest, _ := io.ReadAll(br); string(rest) != "\n\nbody" {
+		t.Errorf("rest: got %q", rest)
+	}
+}
+
+func TestMarkerTerminatedReader(t *testing.T) {
+	//a false start of the marker before the real one
+	const in = "data END\x00 more\nEND\nafter"
+	marker := []byte("\nEND\n")
+	for i := 0; i <= len(in); i++ {
+		src := NewScriptedReader([]byte(in), []int{i, 2})
+		r := NewMarkerTerminatedReader(src, marker)
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != "data END\x00 more" {
+			t.Errorf("split at %d: got %q, %v", i, got, err)
+		}
+		if rest, _ := io.ReadAll(src); string(rest) != "after" {
+			t.Errorf("split at %d: rest %q", i, rest)
+		}
+	}
+}
+
+func TestMarkerTerminatedReaderPartial(t *testing.T) {
+	for _, in := range []string{"data\nEN", "data\nEND", "\nE\nEN\n"} {
+		r := NewMarkerTerminatedReader(iotest.HalfReader(strings.NewReader(in)), []byte("\nEND\n"))
+		if got, err := io.ReadAll(r); err != nil || string(got) != in {
+			t.Errorf("got %q, %v want %q", got, err, in)
+		}
+	}
+}
+
+func TestMarkerTerminatedReaderBufio(t *testing.T) {
+	br := bufio.NewReader(strings.NewReader("header--body"))
+	if got, _ := io.ReadAll(NewMarkerTerminatedReader(br, []byte("--"))); string(got) != "header" {
+		t.Errorf("got %q", got)
+	}
+	if rest, _ := io.ReadAll(br); string(rest) != "body" {
+		t.Errorf("rest: got %q", rest)
+	}
+}
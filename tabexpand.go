@@ -0,0 +1,52 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+)
+
+//NewTabExpandReader returns a Reader that replaces each tab in r
+//with the spaces needed to reach the next multiple of tabStop columns.
+//
+//Columns start at 0 after each \n.
+//Each UTF-8 encoded rune counts as one column,
+//regardless of how it is displayed.
+//Lines may span any number of reads of r.
+//
+//NewTabExpandReader panics if tabStop is not positive.
+func NewTabExpandReader(r io.Reader, tabStop int) *Reader {
+	if tabStop < 1 {
+		panic("tab stop must be positive")
+	}
+	return newTransformReader(r, &tabExpandTransform{tabStop: tabStop})
+}
+
+type tabExpandTransform struct {
+	tabStop int
+	col     int
+}
+
+func (t *tabExpandTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch {
+		case b == '\t':
+			n := t.tabStop - t.col%t.tabStop
+			out = append(out, bytes.Repeat([]byte{' '}, n)...)
+			t.col += n
+		case b == '\n':
+			out = append(out, b)
+			t.col = 0
+		case b&0xc0 == 0x80:
+			//a continuation byte is part of the rune already counted
+			out = append(out, b)
+		default:
+			out = append(out, b)
+			t.col++
+		}
+	}
+	return out, nil
+}
+
+func (t *tabExpandTransform) flush(out []byte) ([]byte, error) {
+	return out, nil
+}
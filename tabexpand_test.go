@@ -0,0 +1,50 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTabExpandReader(t *testing.T) {
+	tests := []struct {
+		in      string
+		tabStop int
+		want    string
+	}{
+		{"\tx", 4, "    x"},
+		{"a\tb", 4, "a   b"},
+		{"abc\td", 4, "abc d"},
+		{"abcd\te", 4, "abcd    e"},
+		{"a\t\tb", 4, "a       b"},
+		{"ab\tc\nd\te", 8, "ab      c\nd       e"},
+		{"é\tx", 4, "é   x"},
+		{"\t", 1, " "},
+		{"no tabs", 4, "no tabs"},
+	}
+	for _, tt := range tests {
+		for i := 0; i <= len(tt.in); i++ {
+			r := NewTabExpandReader(NewScriptedReader([]byte(tt.in), []int{i, 1}), tt.tabStop)
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("%q split at %d: got %q, %v want %q", tt.in, i, got, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestTabExpandReaderSmallBuffer(t *testing.T) {
+	//the expansion of one tab is delivered over several reads
+	r := NewTabExpandReader(strings.NewReader("a\tb\n\tc"), 8)
+	got, err := io.ReadAll(iotest.OneByteReader(r))
+	if want := "a       b\n        c"; err != nil || string(got) != want {
+		t.Errorf("got %q, %v want %q", got, err, want)
+	}
+
+	r = NewTabExpandReader(strings.NewReader("\tab"), 8)
+	p, err := Read(r, make([]byte, 3))
+	if string(p) != "   " || err != nil {
+		t.Errorf("got %q, %v", p, err)
+	}
+}
@@ -0,0 +1,62 @@
+package simple
+
+import "io"
+
+//TailReader is a Reader that remembers the last bytes it delivered.
+type TailReader struct {
+	*Reader
+	t *tailReader
+}
+
+//NewTailReader wraps r in a TailReader remembering the last n bytes
+//delivered, in a ring buffer of n bytes.
+//
+//NewTailReader panics if n is not positive.
+func NewTailReader(r io.Reader, n int) *TailReader {
+	must(r)
+	if n < 1 {
+		panic("tail size must be positive")
+	}
+	t := &tailReader{r: r, ring: make([]byte, n)}
+	return &TailReader{
+		Reader: NewReader(t),
+		t:      t,
+	}
+}
+
+//Tail returns a copy of the last n bytes delivered,
+//or all of them if fewer than n have been.
+func (t *TailReader) Tail() []byte {
+	tr := t.t
+	if !tr.full {
+		return append([]byte(nil), tr.ring[:tr.next]...)
+	}
+	tail := make([]byte, 0, len(tr.ring))
+	tail = append(tail, tr.ring[tr.next:]...)
+	return append(tail, tr.ring[:tr.next]...)
+}
+
+type tailReader struct {
+	r    io.Reader
+	ring []byte
+	next int  //where the next byte goes, and the oldest byte once full
+	full bool //whether ring has wrapped
+}
+
+func (t *tailReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	b := p[:n]
+	//only the last len(ring) bytes can be kept
+	if len(b) > len(t.ring) {
+		b = b[len(b)-len(t.ring):]
+	}
+	for len(b) > 0 {
+		k := copy(t.ring[t.next:], b)
+		b = b[k:]
+		t.next += k
+		if t.next == len(t.ring) {
+			t.next, t.full = 0, true
+		}
+	}
+	return n, err
+}
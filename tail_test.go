@@ -0,0 +1,46 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTailReader(t *testing.T) {
+	const in = "abcdefghijklmnopqrstuvwxyz"
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader} {
+		r := NewTailReader(wrap(strings.NewReader(in)), 5)
+		if got := r.Tail(); len(got) != 0 {
+			t.Errorf("before reading: got %q", got)
+		}
+
+		read := 0
+		for _, upto := range []int{3, 5, 7, 12, 26} {
+			k, _ := io.ReadFull(r, make([]byte, upto-read))
+			read += k
+			want := in[max(read-5, 0):read]
+			if got := r.Tail(); string(got) != want {
+				t.Errorf("after %d: got %q want %q", read, got, want)
+			}
+		}
+	}
+}
+
+func TestTailReaderLargeRead(t *testing.T) {
+	//a single read many times the size of the ring
+	r := NewTailReader(strings.NewReader(strings.Repeat("x", 100)+"tail"), 4)
+	io.ReadAll(r)
+	if got := r.Tail(); string(got) != "tail" {
+		t.Errorf("got %q", got)
+	}
+
+	//the copy is not affected by later reads
+	r = NewTailReader(strings.NewReader("abcdef"), 4)
+	io.ReadFull(r, make([]byte, 4))
+	got := r.Tail()
+	io.ReadAll(r)
+	if string(got) != "abcd" || string(r.Tail()) != "cdef" {
+		t.Errorf("got %q then %q", got, r.Tail())
+	}
+}
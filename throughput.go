@@ -0,0 +1,128 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+//ErrTooSlow is returned by a Reader from NewMinThroughputReader
+//when the wrapped io.Reader falls below the minimum throughput.
+var ErrTooSlow = errors.New("simple: reader too slow")
+
+//NewMinThroughputReader returns a Reader that returns ErrTooSlow
+//if fewer than minBytesPerSec bytes per second, on average,
+//are read from r over the last window,
+//such as to abort a stalled download.
+//
+//Only time spent in reads of r counts,
+//so a caller that is slow to call Read does not slow the reader.
+//Nor is r judged until window has passed,
+//and it is only judged as it is read, as a whole window,
+//so a pause shorter than window is allowed
+//if enough was read in the rest of it.
+//
+//If r has a SetReadDeadline method, as net.Conn and *os.File do,
+//it is used to end a read of r that blocks
+//for long enough to fall below the minimum.
+//The deadline is cleared after each read, so r is left without one,
+//and a timeout from a read that does not fall below the minimum
+//is not returned.
+//Otherwise, such a read cannot be stopped,
+//but ErrTooSlow is returned once it ends.
+//
+//After returning ErrTooSlow, the Reader does not read r again
+//and every Read returns ErrTooSlow.
+//
+//NewMinThroughputReader panics if minBytesPerSec < 1 or window <= 0.
+func NewMinThroughputReader(r io.Reader, minBytesPerSec int, window time.Duration) *Reader {
+	must(r)
+	switch {
+	case minBytesPerSec < 1:
+		panic("minimum throughput must be positive")
+	case window <= 0:
+		panic("window must be positive")
+	}
+	return NewReader(&throughputReader{
+		r:      r,
+		need:   float64(minBytesPerSec) * window.Seconds(),
+		window: window,
+	})
+}
+
+type throughputSample struct {
+	at time.Duration //on the clock, when the read ended
+	n  int
+}
+
+type throughputReader struct {
+	r      io.Reader
+	need   float64 //bytes needed in each window
+	window time.Duration
+
+	clock    time.Duration //total time spent in reads of r
+	samples  []throughputSample
+	inWindow int //bytes in samples
+	slow     bool
+}
+
+func (t *throughputReader) Read(p []byte) (int, error) {
+	if t.slow {
+		return 0, ErrTooSlow
+	}
+
+	d, deadline := t.r.(interface{ SetReadDeadline(time.Time) error })
+	start := time.Now()
+	if deadline {
+		if err := d.SetReadDeadline(start.Add(t.failAt() - t.clock)); err != nil {
+			return 0, err
+		}
+	}
+	n, err := t.r.Read(p)
+	t.clock += time.Since(start)
+	if deadline {
+		if derr := d.SetReadDeadline(time.Time{}); err == nil {
+			err = derr
+		}
+	}
+
+	if n > 0 {
+		t.samples = append(t.samples, throughputSample{t.clock, n})
+		t.inWindow += n
+	}
+	for len(t.samples) > 0 && t.samples[0].at <= t.clock-t.window {
+		t.inWindow -= t.samples[0].n
+		t.samples = t.samples[1:]
+	}
+
+	timeout := deadline && isTimeout(err)
+	if err != nil && !timeout {
+		return n, err
+	}
+	if t.clock >= t.window && float64(t.inWindow) < t.need {
+		t.slow = true
+		return n, ErrTooSlow
+	}
+	if timeout {
+		//the deadline was ours, and did not find r too slow
+		err = nil
+	}
+	return n, err
+}
+
+//failAt returns the earliest time on the clock that the reader
+//could be judged too slow if no more is read.
+func (t *throughputReader) failAt() time.Duration {
+	at, rem := max(t.clock, t.window), t.inWindow
+	for _, s := range t.samples {
+		//s is in the window ending at at
+		if s.at > at-t.window {
+			if float64(rem) < t.need {
+				return at
+			}
+			at = s.at + t.window
+		}
+		rem -= s.n
+	}
+	return at
+}
@@ -0,0 +1,156 @@
+package simple
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+//pacedReader returns chunks of n bytes after waiting for the duration
+//returned by pause for each read, until limit bytes have been read.
+type pacedReader struct {
+	n, read, limit int
+	pause          func(read int) time.Duration
+}
+
+func (p *pacedReader) Read(b []byte) (int, error) {
+	if p.read >= p.limit {
+		return 0, io.EOF
+	}
+	time.Sleep(p.pause(p.read))
+	n := min(p.n, len(b), p.limit-p.read)
+	p.read += n
+	return n, nil
+}
+
+func TestMinThroughputReaderTooSlow(t *testing.T) {
+	//2000 bytes fast, then a byte every 10ms, or 100B/s
+	p := &pacedReader{n: 100, limit: 1 << 20, pause: func(read int) time.Duration {
+		if read < 2000 {
+			return time.Millisecond
+		}
+		return 10 * time.Millisecond
+	}}
+	r := NewMinThroughputReader(p, 1000, 50*time.Millisecond)
+	buf := make([]byte, 100)
+	for {
+		if p.read >= 2000 {
+			p.n = 1
+		}
+		_, err := r.Read(buf)
+		if err == ErrTooSlow {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.read > 2100 {
+			t.Fatal("did not abort")
+		}
+	}
+	if p.read < 2000 {
+		t.Errorf("aborted after %d bytes, while fast", p.read)
+	}
+	if _, err := r.Read(buf); err != ErrTooSlow {
+		t.Errorf("got %v after abort", err)
+	}
+}
+
+func TestMinThroughputReaderPause(t *testing.T) {
+	p := &pacedReader{n: 100, limit: 5000, pause: func(read int) time.Duration {
+		if read == 2000 {
+			return 25 * time.Millisecond
+		}
+		return time.Millisecond
+	}}
+	r := NewMinThroughputReader(p, 1000, 100*time.Millisecond)
+	got, err := io.ReadAll(r)
+	if err != nil || len(got) != 5000 {
+		t.Errorf("got %d bytes, %v", len(got), err)
+	}
+}
+
+//deadlineReader blocks each read until its deadline,
+//after first returning the bytes in data
+//and then timing out early timeouts times.
+type deadlineReader struct {
+	data      []byte
+	timeouts  int
+	deadline  time.Time
+	deadlines []time.Time
+}
+
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	if len(d.data) > 0 {
+		n := copy(p, d.data)
+		d.data = d.data[n:]
+		return n, nil
+	}
+	if d.timeouts > 0 {
+		d.timeouts--
+		return 0, os.ErrDeadlineExceeded
+	}
+	if d.deadline.IsZero() {
+		return 0, errors.New("read would block forever")
+	}
+	time.Sleep(time.Until(d.deadline))
+	return 0, os.ErrDeadlineExceeded
+}
+
+func (d *deadlineReader) SetReadDeadline(t time.Time) error {
+	d.deadline = t
+	d.deadlines = append(d.deadlines, t)
+	return nil
+}
+
+func TestMinThroughputReaderDeadline(t *testing.T) {
+	const window = 50 * time.Millisecond
+	d := &deadlineReader{data: make([]byte, 10)}
+	r := NewMinThroughputReader(d, 1000, window)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := r.Read(make([]byte, 10)); err != ErrTooSlow {
+		t.Fatalf("got %v want ErrTooSlow", err)
+	}
+	//10 bytes are too few to meet the minimum,
+	//so the abort comes as soon as the first window ends
+	if elapsed := time.Since(start); elapsed < window/2 || elapsed > 10*window {
+		t.Errorf("aborted after %v", elapsed)
+	}
+	if !d.deadline.IsZero() {
+		t.Errorf("deadline left set to %v", d.deadline)
+	}
+}
+
+func TestMinThroughputReaderEarlyTimeout(t *testing.T) {
+	d := &deadlineReader{data: make([]byte, 10), timeouts: 2}
+	r := NewMinThroughputReader(d, 1, time.Hour)
+	if _, err := r.Read(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+	//timeouts before r is too slow are not errors
+	for i := 0; i < 2; i++ {
+		if n, err := r.Read(make([]byte, 10)); n != 0 || err != nil {
+			t.Errorf("timeout %d: got %d, %v", i, n, err)
+		}
+	}
+	for i, dl := range d.deadlines {
+		if i%2 == 0 && dl.IsZero() || i%2 == 1 && !dl.IsZero() {
+			t.Errorf("deadline %d is %v", i, dl)
+		}
+	}
+	if len(d.deadlines) != 6 || !d.deadline.IsZero() {
+		t.Errorf("deadlines set: %v", d.deadlines)
+	}
+}
+
+func TestMinThroughputReaderError(t *testing.T) {
+	r := NewMinThroughputReader(&dataErr{"abc", errTruncated}, 1, time.Hour)
+	if got, err := io.ReadAll(r); string(got) != "abc" || err != errTruncated {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,72 @@
+package simple
+
+import (
+	"io"
+	"time"
+)
+
+//ReadStats summarizes the reads of an underlying io.Reader.
+type ReadStats struct {
+	//Reads is the number of calls to the underlying Read.
+	Reads int
+	//Bytes is the total number of bytes returned by those calls.
+	Bytes int64
+	//Min, Max, and Mean are the latencies of those calls.
+	//They are zero if there have been no reads.
+	Min, Max, Mean time.Duration
+}
+
+//TimedReader is a Reader that records how long each read
+//of the wrapped io.Reader takes.
+type TimedReader struct {
+	*Reader
+	t *timedReader
+}
+
+//NewTimedReader wraps r in a TimedReader.
+//
+//Only calls to r.Read are timed.
+//A Read that returns an error stored from a previous read does not call r.Read,
+//so it is not counted;
+//the read that stored the error is counted along with its data.
+func NewTimedReader(r io.Reader) *TimedReader {
+	must(r)
+	t := &timedReader{r: r}
+	return &TimedReader{
+		Reader: NewReader(t),
+		t:      t,
+	}
+}
+
+//Stats reports the reads made so far.
+func (t *TimedReader) Stats() ReadStats {
+	s := t.t.stats
+	if s.Reads > 0 {
+		s.Mean = t.t.total / time.Duration(s.Reads)
+	}
+	return s
+}
+
+type timedReader struct {
+	r     io.Reader
+	stats ReadStats
+	total time.Duration
+}
+
+func (t *timedReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := t.r.Read(p)
+	d := time.Since(start)
+
+	s := &t.stats
+	if s.Reads == 0 || d < s.Min {
+		s.Min = d
+	}
+	if d > s.Max {
+		s.Max = d
+	}
+	s.Reads++
+	s.Bytes += int64(n)
+	t.total += d
+	return n, err
+}
@@ -0,0 +1,55 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+//sleepy sleeps for d before each read of r.
+type sleepy struct {
+	r io.Reader
+	d time.Duration
+}
+
+func (s sleepy) Read(p []byte) (int, error) {
+	time.Sleep(s.d)
+	return s.r.Read(p)
+}
+
+func TestTimedReader(t *testing.T) {
+	const d = 10 * time.Millisecond
+	b := NewBasic("Hello, World!")
+	r := NewTimedReader(sleepy{&b, d})
+
+	p := make([]byte, 10)
+	for {
+		if _, err := r.Read(p); err != nil {
+			break
+		}
+	}
+
+	//"Hello, Wor" then "ld!" with EOF, with the EOF returned
+	//by the wrapper without another read
+	s := r.Stats()
+	if s.Reads != 2 {
+		t.Errorf("got %d reads, want 2", s.Reads)
+	}
+	if s.Bytes != 13 {
+		t.Errorf("got %d bytes, want 13", s.Bytes)
+	}
+	if s.Min < d || s.Max < s.Min || s.Mean < s.Min || s.Mean > s.Max {
+		t.Errorf("bad latencies %+v", s)
+	}
+	if s.Max > 50*d {
+		t.Errorf("implausible max latency %v", s.Max)
+	}
+}
+
+func TestTimedReaderNoReads(t *testing.T) {
+	r := NewTimedReader(strings.NewReader(""))
+	if s := r.Stats(); s != (ReadStats{}) {
+		t.Errorf("got %+v", s)
+	}
+}
@@ -0,0 +1,72 @@
+package simple
+
+import "io"
+
+//transformer is a streaming transformation of bytes.
+//
+//It must hold any state, including bytes withheld while waiting
+//to see what follows them, between calls.
+type transformer interface {
+	//push appends the output for in to out.
+	//All of in is consumed.
+	push(out, in []byte) ([]byte, error)
+	//flush appends any withheld output to out at the end of the stream.
+	flush(out []byte) ([]byte, error)
+}
+
+//transformReader applies a transformer to an io.Reader.
+//
+//Output that does not fit in the caller's buffer is kept for the next Read.
+//An error from the transformer is returned after the output
+//that preceded it.
+type transformReader struct {
+	r   io.Reader
+	t   transformer
+	in  []byte
+	out []byte
+	off int   //start of the undelivered output in out
+	err error //returned once out is delivered
+}
+
+func newTransformReader(r io.Reader, t transformer) *Reader {
+	must(r)
+	return NewReader(&transformReader{r: r, t: t})
+}
+
+func (t *transformReader) Read(p []byte) (int, error) {
+	for {
+		if t.off < len(t.out) {
+			n := copy(p, t.out[t.off:])
+			t.off += n
+			return n, nil
+		}
+		if t.err != nil {
+			return 0, t.err
+		}
+		if len(p) == 0 {
+			return 0, nil
+		}
+
+		if cap(t.in) < len(p) {
+			t.in = make([]byte, len(p))
+		}
+		n, err := t.r.Read(t.in[:len(p)])
+
+		var terr error
+		t.out, t.off = t.out[:0], 0
+		t.out, terr = t.t.push(t.out, t.in[:n])
+		switch {
+		case terr != nil:
+			t.err = terr
+		case err == io.EOF:
+			t.out, t.err = t.t.flush(t.out)
+			if t.err == nil {
+				t.err = io.EOF
+			}
+		case err != nil:
+			t.err = err
+		case n == 0:
+			return 0, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package simple
+
+import "io"
+
+//NewTrimReader returns a Reader that removes any bytes in cutset
+//from the start and end of each line of r.
+//
+//Lines end with \n, which is never removed.
+//A \r before it is part of the line, unless it is in cutset.
+//Bytes at the end of a line are held back until it is known
+//whether anything but cutset follows them on the line,
+//so lines may span any number of reads of r.
+//The last line of r need not end with \n.
+//
+//Each byte of cutset is a byte to remove:
+//unlike strings.Trim, cutset is not interpreted as UTF-8.
+func NewTrimReader(r io.Reader, cutset string) *Reader {
+	return newTransformReader(r, newTrimTransform(cutset, true, true))
+}
+
+//NewTrimLeftReader is like NewTrimReader
+//but only removes bytes from the start of each line.
+func NewTrimLeftReader(r io.Reader, cutset string) *Reader {
+	return newTransformReader(r, newTrimTransform(cutset, true, false))
+}
+
+//NewTrimRightReader is like NewTrimReader
+//but only removes bytes from the end of each line.
+func NewTrimRightReader(r io.Reader, cutset string) *Reader {
+	return newTransformReader(r, newTrimTransform(cutset, false, true))
+}
+
+func newTrimTransform(cutset string, left, right bool) *trimTransform {
+	t := &trimTransform{left: left, right: right, start: true}
+	for i := 0; i < len(cutset); i++ {
+		t.cut[cutset[i]] = true
+	}
+	return t
+}
+
+type trimTransform struct {
+	cut         [256]bool
+	left, right bool
+	start       bool   //whether nothing but cutset has been seen on this line
+	held        []byte //cutset bytes that may end the line
+}
+
+func (t *trimTransform) push(out, in []byte) ([]byte, error) {
+	for _, b := range in {
+		switch {
+		case b == '\n':
+			t.held = t.held[:0]
+			t.start = true
+			out = append(out, b)
+		case !t.cut[b]:
+			t.start = false
+			out = append(out, t.held...)
+			t.held = t.held[:0]
+			out = append(out, b)
+		case t.start && t.left:
+		case t.right:
+			t.held = append(t.held, b)
+		default:
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func (t *trimTransform) flush(out []byte) ([]byte, error) {
+	return out, nil
+}
@@ -0,0 +1,36 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTrimReader(t *testing.T) {
+	const in = "  \tone two \t\n\n \t \nthree\n\t four  "
+	cases := []struct {
+		name string
+		mk   func(io.Reader, string) *Reader
+		want string
+	}{
+		{"both", NewTrimReader, "one two\n\n\nthree\nfour"},
+		{"left", NewTrimLeftReader, "one two \t\n\n\nthree\nfour  "},
+		{"right", NewTrimRightReader, "  \tone two\n\n\nthree\n\t four"},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(c.mk(wrap(strings.NewReader(in)), " \t"))
+			if err != nil || string(got) != c.want {
+				t.Errorf("%s: got %q, %v want %q", c.name, got, err, c.want)
+			}
+		}
+	}
+}
+
+func TestTrimReaderCRLF(t *testing.T) {
+	got, err := io.ReadAll(NewTrimReader(strings.NewReader(" a \r\n b \r\n"), " \r"))
+	if err != nil || string(got) != "a\nb\n" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,50 @@
+package simple
+
+import (
+	"io"
+	"time"
+)
+
+//TTFBReader is a Reader that measures the time to its first byte.
+type TTFBReader struct {
+	*Reader
+	t *ttfbReader
+}
+
+//NewTTFBReader wraps r in a TTFBReader.
+//
+//The time is measured from the start of the first Read
+//until a read of r first returns data.
+//Reads of r that return no data, such as 0, nil, do not end the measurement.
+func NewTTFBReader(r io.Reader) *TTFBReader {
+	must(r)
+	t := &ttfbReader{r: r}
+	return &TTFBReader{
+		Reader: NewReader(t),
+		t:      t,
+	}
+}
+
+//TimeToFirstByte returns the time from the start of the first Read
+//until the first byte was read,
+//or 0 if no byte has been read.
+func (t *TTFBReader) TimeToFirstByte() time.Duration {
+	return t.t.ttfb
+}
+
+type ttfbReader struct {
+	r     io.Reader
+	start time.Time
+	ttfb  time.Duration
+}
+
+func (t *ttfbReader) Read(p []byte) (int, error) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+	n, err := t.r.Read(p)
+	if n > 0 && t.ttfb == 0 {
+		t.ttfb = max(time.Since(t.start), 1)
+	}
+	return n, err
+}
@@ -0,0 +1,46 @@
+package simple
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTTFBReader(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	//the first byte arrives after a delay spread over several empty reads
+	var first time.Time
+	src := readFunc(func(p []byte) (int, error) {
+		if first.IsZero() {
+			first = time.Now()
+		}
+		if time.Since(first) < delay {
+			time.Sleep(delay / 5)
+			return 0, nil
+		}
+		return copy(p, "x"), io.EOF
+	})
+
+	r := NewTTFBReader(src)
+	time.Sleep(delay) //not counted
+	if got := r.TimeToFirstByte(); got != 0 {
+		t.Errorf("before reading: got %v", got)
+	}
+	for {
+		if _, err := r.Read(make([]byte, 1)); err != nil {
+			break
+		}
+	}
+	if got := r.TimeToFirstByte(); got < delay || got > 10*delay {
+		t.Errorf("got %v want about %v", got, delay)
+	}
+}
+
+func TestTTFBReaderNoData(t *testing.T) {
+	r := NewTTFBReader(strings.NewReader(""))
+	io.ReadAll(r)
+	if got := r.TimeToFirstByte(); got != 0 {
+		t.Errorf("got %v", got)
+	}
+}
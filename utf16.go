@@ -0,0 +1,97 @@
+package simple
+
+import (
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+//NewUTF16Reader returns a Reader of the UTF-16 text of r,
+//in byteOrder, decoded to UTF-8.
+//
+//Code units and surrogate pairs may span any number of reads of r.
+//An unpaired surrogate is replaced by utf8.RuneError, U+FFFD.
+//A stream ending within a code unit returns io.ErrUnexpectedEOF
+//after the rest.
+//A byte order mark is decoded like any other character.
+func NewUTF16Reader(r io.Reader, byteOrder binary.ByteOrder) *Reader {
+	return newTransformReader(r, &utf16Transform{order: byteOrder})
+}
+
+//NewUTF16BOMReader is like NewUTF16Reader except that the byte order
+//is given by a byte order mark at the start of r, which is removed.
+//If r does not start with one, fallback is used.
+func NewUTF16BOMReader(r io.Reader, fallback binary.ByteOrder) *Reader {
+	return newTransformReader(r, &utf16Transform{order: fallback, bom: true})
+}
+
+type utf16Transform struct {
+	order binary.ByteOrder
+	bom   bool   //whether to look for a byte order mark
+	odd   []byte //the first byte of a code unit
+	high  rune   //the first half of a surrogate pair, or 0
+}
+
+func (t *utf16Transform) push(out, in []byte) ([]byte, error) {
+	if len(t.odd) > 0 {
+		if len(in) == 0 {
+			return out, nil
+		}
+		unit := [2]byte{t.odd[0], in[0]}
+		t.odd, in = t.odd[:0], in[1:]
+		out = t.unit(out, unit[:])
+	}
+	for ; len(in) >= 2; in = in[2:] {
+		out = t.unit(out, in[:2])
+	}
+	if len(in) == 1 {
+		t.odd = append(t.odd, in[0])
+	}
+	return out, nil
+}
+
+//unit appends the output for the code unit in b.
+func (t *utf16Transform) unit(out, b []byte) []byte {
+	if t.bom {
+		t.bom = false
+		switch {
+		case b[0] == 0xfe && b[1] == 0xff:
+			t.order = binary.BigEndian
+			return out
+		case b[0] == 0xff && b[1] == 0xfe:
+			t.order = binary.LittleEndian
+			return out
+		}
+	}
+
+	r := rune(t.order.Uint16(b))
+	if t.high != 0 {
+		high := t.high
+		t.high = 0
+		if dec := utf16.DecodeRune(high, r); dec != utf8.RuneError {
+			return utf8.AppendRune(out, dec)
+		}
+		out = utf8.AppendRune(out, utf8.RuneError)
+	}
+	switch {
+	case 0xd800 <= r && r < 0xdc00:
+		t.high = r
+	case 0xdc00 <= r && r < 0xe000:
+		out = utf8.AppendRune(out, utf8.RuneError)
+	default:
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
+
+func (t *utf16Transform) flush(out []byte) ([]byte, error) {
+	if t.high != 0 {
+		t.high = 0
+		out = utf8.AppendRune(out, utf8.RuneError)
+	}
+	if len(t.odd) > 0 {
+		return out, io.ErrUnexpectedEOF
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package simple
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+//encodeUTF16 encodes s, followed by any extra code units, in order.
+func encodeUTF16(order binary.ByteOrder, s string, extra ...uint16) []byte {
+	var out []byte
+	for _, u := range append(utf16.Encode([]rune(s)), extra...) {
+		out = append(out, 0, 0)
+		order.PutUint16(out[len(out)-2:], u)
+	}
+	return out
+}
+
+func TestUTF16Reader(t *testing.T) {
+	//𝄞 and 😀 are surrogate pairs
+	const s = "héllo 𝄞 wörld 😀!"
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		in := encodeUTF16(order, s)
+		for i := 0; i <= len(in); i++ {
+			got, err := io.ReadAll(NewUTF16Reader(NewScriptedReader(in, []int{i, 1, 3}), order))
+			if err != nil || string(got) != s {
+				t.Errorf("%v split at %d: got %q, %v", order, i, got, err)
+			}
+		}
+	}
+}
+
+func TestUTF16ReaderInvalid(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want string
+		err  error
+	}{
+		{encodeUTF16(binary.BigEndian, "a", 0xd834), "a\ufffd", nil},
+		{encodeUTF16(binary.BigEndian, "a", 0xd834, 'b'), "a\ufffdb", nil},
+		{encodeUTF16(binary.BigEndian, "a", 0xdd1e, 'b'), "a\ufffdb", nil},
+		{append(encodeUTF16(binary.BigEndian, "ab"), 0), "ab", io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		got, err := io.ReadAll(NewUTF16Reader(NewScriptedReader(tt.in, []int{1}), binary.BigEndian))
+		if err != tt.err || string(got) != tt.want {
+			t.Errorf("% x: got %q, %v want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestUTF16BOMReader(t *testing.T) {
+	const s = "\ufeffBOM 𝄞"
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		in := encodeUTF16(order, s)
+		for i := 0; i <= len(in); i++ {
+			//the fallback is the wrong order, so the BOM must be used
+			fallback := binary.ByteOrder(binary.LittleEndian)
+			if order == binary.LittleEndian {
+				fallback = binary.BigEndian
+			}
+			got, err := io.ReadAll(NewUTF16BOMReader(NewScriptedReader(in, []int{i, 1}), fallback))
+			if err != nil || string(got) != strings.TrimPrefix(s, "\ufeff") {
+				t.Errorf("%v split at %d: got %q, %v", order, i, got, err)
+			}
+		}
+
+		//without a BOM
+		got, _ := io.ReadAll(NewUTF16BOMReader(NewScriptedReader(encodeUTF16(order, "no BOM"), []int{3}), order))
+		if string(got) != "no BOM" {
+			t.Errorf("%v: got %q", order, got)
+		}
+	}
+
+	//a BOM is only removed at the start
+	got, _ := io.ReadAll(NewUTF16BOMReader(NewScriptedReader(encodeUTF16(binary.BigEndian, "a\ufeff"), []int{1}), binary.BigEndian))
+	if string(got) != "a\ufeff" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package simple
+
+import (
+	"fmt"
+	"io"
+)
+
+//ValidationError reports a byte rejected by the step function
+//of a Reader from NewValidatingReader.
+type ValidationError struct {
+	Byte   byte
+	Offset int64
+	//Err is the error returned by the step function.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("simple: invalid byte %q at offset %d: %v", e.Byte, e.Offset, e.Err)
+}
+
+//Unwrap returns Err.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+//NewValidatingReader returns a Reader that delivers the bytes of r
+//up to the first byte rejected by step,
+//then returns a *ValidationError.
+//
+//step is called with each byte in turn and the state returned for the byte
+//before it, starting with state 0, and returns the next state
+//or an error to reject the byte.
+//State carries across reads of r, so step can enforce a simple grammar.
+//
+//step only sees bytes, so it cannot reject a stream that ends
+//in the middle of a production.
+func NewValidatingReader(r io.Reader, step func(state int, b byte) (int, error)) *Reader {
+	must(r)
+	return NewReader(&validatingReader{r: r, step: step})
+}
+
+type validatingReader struct {
+	r     io.Reader
+	step  func(int, byte) (int, error)
+	state int
+	off   int64
+	err   error
+}
+
+func (v *validatingReader) Read(p []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+
+	n, err := v.r.Read(p)
+	for i, b := range p[:n] {
+		next, serr := v.step(v.state, b)
+		if serr != nil {
+			v.err = &ValidationError{Byte: b, Offset: v.off + int64(i), Err: serr}
+			return i, v.err
+		}
+		v.state = next
+	}
+	v.off += int64(n)
+	return n, err
+}
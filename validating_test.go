@@ -0,0 +1,76 @@
+package simple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var errNotInteger = errors.New("not a decimal integer")
+
+//decimalStep validates decimal integers, one per line,
+//with an optional sign and no leading zeros.
+func decimalStep(state int, b byte) (int, error) {
+	const (
+		start  = iota //start of a line
+		sign          //after a sign
+		zero          //after a lone 0
+		digits        //after a nonzero digit
+	)
+	switch {
+	case b == '\n' && (state == zero || state == digits):
+		return start, nil
+	case b == '-' && state == start:
+		return sign, nil
+	case b == '0' && (state == start || state == sign):
+		return zero, nil
+	case '0' <= b && b <= '9' && state != zero:
+		return digits, nil
+	}
+	return state, errNotInteger
+}
+
+func ExampleNewValidatingReader() {
+	r := NewValidatingReader(strings.NewReader("12\n-7\n0\n012\n"), decimalStep)
+	got, err := io.ReadAll(r)
+	fmt.Printf("%q\n", got)
+	fmt.Println(err)
+	// Output:
+	// "12\n-7\n0\n0"
+	// simple: invalid byte '1' at offset 9: not a decimal integer
+}
+
+func TestValidatingReader(t *testing.T) {
+	cases := []struct {
+		in     string
+		valid  string
+		reject bool
+	}{
+		{"1\n22\n-333\n0\n-0\n", "1\n22\n-333\n0\n-0\n", false},
+		{"12\n3x\n", "12\n3", true},
+		{"--1\n", "-", true},
+		{"1\n\n", "1\n", true},
+		{"00\n", "0", true},
+		{"+1\n", "", true},
+	}
+	for _, c := range cases {
+		for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+			got, err := io.ReadAll(NewValidatingReader(wrap(strings.NewReader(c.in)), decimalStep))
+			if string(got) != c.valid {
+				t.Errorf("%q: got %q want %q", c.in, got, c.valid)
+			}
+			var verr *ValidationError
+			switch {
+			case !c.reject && err != nil:
+				t.Errorf("%q: %v", c.in, err)
+			case c.reject && (!errors.As(err, &verr) || !errors.Is(err, errNotInteger)):
+				t.Errorf("%q: got %v want a ValidationError", c.in, err)
+			case c.reject && verr.Offset != int64(len(c.valid)):
+				t.Errorf("%q: rejected at %d want %d", c.in, verr.Offset, len(c.valid))
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package simple
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+//ErrVarintOverflow is returned by ReadUvarint and ReadVarint
+//for a varint that does not fit in 64 bits.
+var ErrVarintOverflow = errors.New("simple: varint overflows a 64-bit integer")
+
+//ReadUvarint reads an unsigned base-128 varint,
+//as encoded by binary.PutUvarint and used by protocol buffers.
+//
+//r is read one byte at a time, so nothing past the varint is consumed.
+//ReadUvarint returns io.EOF only if the stream ends before the varint begins.
+//If it ends within the varint, ReadUvarint returns io.ErrUnexpectedEOF.
+func (r *Reader) ReadUvarint() (uint64, error) {
+	br := &byteReader{r: r}
+	var x uint64
+	var s uint
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			if i > 0 {
+				err = noEOF(err)
+			}
+			return 0, err
+		}
+		if b < 0x80 {
+			if i == binary.MaxVarintLen64-1 && b > 1 {
+				return 0, ErrVarintOverflow
+			}
+			return x | uint64(b)<<s, nil
+		}
+		x |= uint64(b&0x7f) << s
+		s += 7
+	}
+	return 0, ErrVarintOverflow
+}
+
+//ReadVarint reads a signed, zig-zag encoded, base-128 varint,
+//as encoded by binary.PutVarint, as by ReadUvarint.
+func (r *Reader) ReadVarint() (int64, error) {
+	ux, err := r.ReadUvarint()
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, err
+}
@@ -0,0 +1,69 @@
+package simple
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadUvarint(t *testing.T) {
+	values := []uint64{0, 1, 127, 128, 300, 1 << 32, math.MaxUint64}
+	var in []byte
+	for _, v := range values {
+		in = binary.AppendUvarint(in, v)
+	}
+	in = append(in, "rest"...)
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		r := NewReader(wrap(bytes.NewReader(in)))
+		for _, want := range values {
+			if got, err := r.ReadUvarint(); err != nil || got != want {
+				t.Fatalf("got %d, %v want %d", got, err, want)
+			}
+		}
+		if rest, err := io.ReadAll(r); err != nil || string(rest) != "rest" {
+			t.Errorf("rest: got %q, %v", rest, err)
+		}
+		if _, err := r.ReadUvarint(); err != io.EOF {
+			t.Errorf("got %v want io.EOF", err)
+		}
+	}
+
+	if n := len(binary.AppendUvarint(nil, math.MaxUint64)); n != binary.MaxVarintLen64 {
+		t.Fatalf("max width varint is %d bytes", n)
+	}
+}
+
+func TestReadVarint(t *testing.T) {
+	values := []int64{0, -1, 1, -64, 64, math.MinInt64, math.MaxInt64}
+	var in []byte
+	for _, v := range values {
+		in = binary.AppendVarint(in, v)
+	}
+	r := NewReader(iotest.OneByteReader(bytes.NewReader(in)))
+	for _, want := range values {
+		if got, err := r.ReadVarint(); err != nil || got != want {
+			t.Fatalf("got %d, %v want %d", got, err, want)
+		}
+	}
+}
+
+func TestReadUvarintErrors(t *testing.T) {
+	cases := []struct {
+		in   string
+		want error
+	}{
+		{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\x02", ErrVarintOverflow},
+		{"\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01", ErrVarintOverflow},
+		{"\x80\x80", io.ErrUnexpectedEOF},
+		{"", io.EOF},
+	}
+	for _, c := range cases {
+		if _, err := NewReader(bytes.NewReader([]byte(c.in))).ReadUvarint(); err != c.want {
+			t.Errorf("%q: got %v want %v", c.in, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package simple
+
+import (
+	"errors"
+	"io"
+)
+
+//ErrKeystreamExhausted is returned by a Reader from NewXORReader
+//when the keystream ends before the data.
+var ErrKeystreamExhausted = errors.New("simple: keystream exhausted")
+
+//NewXORReader returns a Reader that delivers the bytes of data
+//each XORed with the next byte of keystream.
+//
+//If keystream ends before data, the bytes of data it covered are delivered,
+//followed by ErrKeystreamExhausted,
+//rather than ending the stream early or delivering data unXORed.
+//Other errors from either input are returned after the data before them.
+func NewXORReader(data, keystream io.Reader) *Reader {
+	must(data)
+	must(keystream)
+	return NewReader(&xorReader{
+		data: NewReader(data),
+		key:  NewReader(keystream),
+	})
+}
+
+type xorReader struct {
+	data, key *Reader
+	buf       []byte
+	err       error
+}
+
+func (x *xorReader) Read(p []byte) (int, error) {
+	if x.err != nil {
+		return 0, x.err
+	}
+
+	n, err := x.data.Read(p)
+	if n == 0 {
+		return 0, err
+	}
+
+	if cap(x.buf) < n {
+		x.buf = make([]byte, n)
+	}
+	k, kerr := io.ReadFull(x.key, x.buf[:n])
+	for i, b := range x.buf[:k] {
+		p[i] ^= b
+	}
+	switch kerr {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		x.err = ErrKeystreamExhausted
+	default:
+		x.err = kerr
+	}
+	return k, x.err
+}
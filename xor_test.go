@@ -0,0 +1,44 @@
+package simple
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestXORReader(t *testing.T) {
+	data := []byte("attack at dawn, or possibly a little after breakfast")
+	key := make([]byte, len(data)+10)
+	rand.New(rand.NewSource(1)).Read(key)
+
+	for _, wrap := range []func(io.Reader) io.Reader{iotest.OneByteReader, iotest.HalfReader, iotest.DataErrReader} {
+		enc, err := io.ReadAll(NewXORReader(wrap(bytes.NewReader(data)), wrap(bytes.NewReader(key))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(enc, data) || len(enc) != len(data) {
+			t.Fatalf("got %q", enc)
+		}
+
+		dec, err := io.ReadAll(NewXORReader(wrap(bytes.NewReader(enc)), NewScriptedReader(key, []int{3, 1})))
+		if err != nil || !bytes.Equal(dec, data) {
+			t.Errorf("got %q, %v", dec, err)
+		}
+	}
+}
+
+func TestXORReaderKeystreamExhausted(t *testing.T) {
+	got, err := io.ReadAll(NewXORReader(strings.NewReader("abcdef"), strings.NewReader("\x00\x00\x00")))
+	if err != ErrKeystreamExhausted || string(got) != "abc" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	//exactly enough keystream is fine
+	got, err = io.ReadAll(NewXORReader(strings.NewReader("abc"), strings.NewReader("\x00\x00\x00")))
+	if err != nil || string(got) != "abc" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}